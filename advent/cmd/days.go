@@ -0,0 +1,15 @@
+package main
+
+import (
+	_ "github.com/pedrokiefer/adventofcode-2023/day1"
+	_ "github.com/pedrokiefer/adventofcode-2023/day14"
+	_ "github.com/pedrokiefer/adventofcode-2023/day15"
+	_ "github.com/pedrokiefer/adventofcode-2023/day16"
+	_ "github.com/pedrokiefer/adventofcode-2023/day17"
+	_ "github.com/pedrokiefer/adventofcode-2023/day19"
+	_ "github.com/pedrokiefer/adventofcode-2023/day2"
+	_ "github.com/pedrokiefer/adventofcode-2023/day3"
+	_ "github.com/pedrokiefer/adventofcode-2023/day4"
+	_ "github.com/pedrokiefer/adventofcode-2023/day5"
+	_ "github.com/pedrokiefer/adventofcode-2023/day6"
+)
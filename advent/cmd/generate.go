@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/template"
+)
+
+//go:embed templates/main_template.tpl
+var mainFile string
+
+//go:embed templates/test_template.tpl
+var testFile string
+
+func generate(args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to generate")
+	name := fs.String("name", "", "name of the struct")
+	fs.Parse(args)
+
+	if *day == 0 {
+		return errors.New("must provide a day")
+	}
+	if *name == "" {
+		return errors.New("must provide a name")
+	}
+
+	values := make(map[string]interface{})
+	values["Day"] = *day
+	values["Name"] = *name
+
+	mainTpl, err := template.New("main").Parse(mainFile)
+	if err != nil {
+		return err
+	}
+	testTpl, err := template.New("test").Parse(testFile)
+	if err != nil {
+		return err
+	}
+
+	main := processTemplate(mainTpl, values)
+	test := processTemplate(testTpl, values)
+
+	os.Mkdir(fmt.Sprintf("day%d", *day), 0755)
+	mainFile, err := os.Create(fmt.Sprintf("day%d/day%d.go", *day, *day))
+	if err != nil {
+		return err
+	}
+	defer mainFile.Close()
+	mainFile.WriteString(main)
+
+	testFile, err := os.Create(fmt.Sprintf("day%d/day%d_test.go", *day, *day))
+	if err != nil {
+		return err
+	}
+	defer testFile.Close()
+	testFile.WriteString(test)
+
+	fmt.Printf("Remember to import day%d in advent/cmd/days.go\n", *day)
+	return nil
+}
+
+func processTemplate(t *template.Template, vars map[string]interface{}) string {
+	var tmplBytes bytes.Buffer
+
+	err := t.Execute(&tmplBytes, vars)
+	if err != nil {
+		panic(err)
+	}
+	return tmplBytes.String()
+}
@@ -1,72 +1,35 @@
 package main
 
 import (
-	"bytes"
-	_ "embed"
-	"flag"
 	"fmt"
-	"log"
 	"os"
-	"text/template"
 )
 
-//go:embed templates/main_template.tpl
-var mainFile string
-
-//go:embed templates/test_template.tpl
-var testFile string
-
-var day = flag.Int("day", 0, "day to generate")
-var name = flag.String("name", "", "name of the struct")
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: %s <command> [flags]\n\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "commands:\n")
+	fmt.Fprintf(os.Stderr, "  generate  scaffold a new day\n")
+	fmt.Fprintf(os.Stderr, "  run       run the solver of one or all days\n")
+}
 
 func main() {
-	flag.Parse()
-	if *day == 0 {
-		log.Fatal("must provide a day")
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
 	}
-	if *name == "" {
-		log.Fatal("must provide a name")
-	}
-
-	values := make(map[string]interface{})
-	values["Day"] = *day
-	values["Name"] = *name
 
-	mainTpl, err := template.New("main").Parse(mainFile)
-	if err != nil {
-		log.Fatal(err)
-	}
-	testTpl, err := template.New("test").Parse(testFile)
-	if err != nil {
-		log.Fatal(err)
+	var err error
+	switch os.Args[1] {
+	case "generate":
+		err = generate(os.Args[2:])
+	case "run":
+		err = run(os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
 	}
-
-	main := processTemplate(mainTpl, values)
-	test := processTemplate(testTpl, values)
-
-	os.Mkdir(fmt.Sprintf("day%d", *day), 0755)
-	mainFile, err := os.Create(fmt.Sprintf("day%d/main.go", *day))
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer mainFile.Close()
-	mainFile.WriteString(main)
-
-	testFile, err := os.Create(fmt.Sprintf("day%d/day%d_test.go", *day, *day))
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer testFile.Close()
-	testFile.WriteString(test)
-
-}
-
-func processTemplate(t *template.Template, vars map[string]interface{}) string {
-	var tmplBytes bytes.Buffer
-
-	err := t.Execute(&tmplBytes, vars)
 	if err != nil {
-		panic(err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
-	return tmplBytes.String()
 }
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pedrokiefer/adventofcode-2023/advent"
+)
+
+func run(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to run, all days when omitted")
+	part := fs.Int("part", 0, "part to run, both parts when omitted")
+	input := fs.String("input", "", "puzzle input, defaults to dayN/input.txt")
+	format := fs.String("format", "text", "output format: text, json or csv")
+	fs.Var(advent.VerbosityFlag(), "v", "log more, repeat or set a level (warn, info, debug) for even more; also read from "+advent.VerboseEnv)
+	fs.Parse(args)
+
+	if *part < 0 || *part > 2 {
+		return fmt.Errorf("invalid part: %d", *part)
+	}
+
+	if *day == 0 && *input != "" {
+		return errors.New("-input requires -day")
+	}
+
+	w, err := advent.NewResultWriter(*format, os.Stdout)
+	if err != nil {
+		return err
+	}
+	err = runDays(w, *day, *part, *input)
+	if ferr := w.Flush(); err == nil {
+		err = ferr
+	}
+	return err
+}
+
+// runDays runs a single day, or every day with an input when day is zero.
+func runDays(w advent.ResultWriter, day, part int, input string) error {
+	if day != 0 {
+		filename := input
+		if filename == "" {
+			filename = defaultInput(day)
+		}
+		return runDay(w, day, part, filename)
+	}
+
+	failed := 0
+	for _, d := range advent.Days() {
+		filename := defaultInput(d)
+		if _, err := os.Stat(filename); errors.Is(err, os.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "day %d: skipped, %s not found\n", d, filename)
+			continue
+		}
+		err := runDay(w, d, part, filename)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "day %d: %v\n", d, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d day(s) failed", failed)
+	}
+	return nil
+}
+
+func defaultInput(day int) string {
+	return fmt.Sprintf("day%d/input.txt", day)
+}
+
+func runDay(w advent.ResultWriter, day, part int, filename string) error {
+	s, err := advent.NewSolver(day)
+	if err != nil {
+		return err
+	}
+
+	input, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	hash := advent.InputHash(input)
+	parseStart := time.Now()
+	if err := s.Parse(io.NopCloser(bytes.NewReader(input))); err != nil {
+		var pe *advent.ParseError
+		if errors.As(err, &pe) {
+			setFile(err, filename)
+			return err
+		}
+		return fmt.Errorf("parsing %s: %w", filename, err)
+	}
+	parsed := time.Since(parseStart)
+
+	parts := []func() (int, error){s.Part1, s.Part2}
+	for i, solve := range parts {
+		if part != 0 && part != i+1 {
+			continue
+		}
+		start := time.Now()
+		answer, err := solve()
+		if err != nil {
+			setFile(err, filename)
+			return fmt.Errorf("part %d: %w", i+1, err)
+		}
+		err = w.Write(advent.Result{
+			Day:           day,
+			Part:          i + 1,
+			Answer:        answer,
+			Duration:      time.Since(start),
+			ParseDuration: parsed,
+			InputHash:     hash,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setFile fills in the file of a ParseError that has none.
+func setFile(err error, filename string) {
+	var pe *advent.ParseError
+	if errors.As(err, &pe) && pe.File == "" {
+		pe.File = filename
+	}
+}
@@ -1,10 +1,10 @@
-package main
+package day{{ .Day }}
 
 import (
-    "os"
-    "log"
 	"bufio"
 	"io"
+
+	"github.com/pedrokiefer/adventofcode-2023/advent"
 )
 
 func InputTo{{ .Name }}(input io.ReadCloser) string {
@@ -16,12 +16,23 @@ func InputTo{{ .Name }}(input io.ReadCloser) string {
 	return ""
 }
 
-func main() {
-	filename := os.Args[1]
-	f, err := os.Open(filename)
-	if err != nil {
-		log.Fatal(err)
-	}
+type Solver struct {
+	v string
+}
+
+func init() {
+	advent.Register({{ .Day }}, func() advent.Solver { return &Solver{} })
+}
+
+func (s *Solver) Parse(input io.ReadCloser) error {
+	s.v = InputTo{{ .Name }}(input)
+	return nil
+}
+
+func (s *Solver) Part1() (int, error) {
+	return 0, advent.ErrNotImplemented
+}
 
-	_ = InputTo{{ .Name }}(f)
+func (s *Solver) Part2() (int, error) {
+	return 0, advent.ErrNotImplemented
 }
@@ -0,0 +1,208 @@
+package advent
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Grid is a dense rectangular map of cells indexed by Point, with (0,0) on
+// the top left corner.
+type Grid[T any] struct {
+	Width  int
+	Height int
+	Cells  []T
+
+	// origins holds where every row of a parsed grid was read from.
+	origins []origin
+}
+
+// origin is the line a row was read from and how many blanks it was
+// indented by.
+type origin struct {
+	line int
+	lead int
+}
+
+func NewGrid[T any](width, height int) *Grid[T] {
+	return &Grid[T]{
+		Width:  width,
+		Height: height,
+		Cells:  make([]T, width*height),
+	}
+}
+
+// ParseGrid reads one row per line, turning every rune into a cell with
+// decode. Blank lines are skipped and all rows must have the same width.
+// Rows that cannot be read are skipped in Lenient mode and returned as
+// ParseErrors in Strict mode.
+func ParseGrid[T any](input io.Reader, mode ParseMode, decode func(rune) (T, error)) (*Grid[T], error) {
+	g := &Grid[T]{}
+	s := bufio.NewScanner(input)
+	s.Buffer(nil, 1024*1024)
+	line := 0
+	for s.Scan() {
+		line++
+		l := s.Text()
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		lead := len(l) - len(strings.TrimLeft(l, " \t"))
+		cells, err := parseRow(strings.TrimSpace(l), lead, g.Width, g.Height == 0, decode)
+		if err != nil {
+			if mode == Strict {
+				return nil, AtLine(err, line, "")
+			}
+			continue
+		}
+		g.Width = len(cells)
+		g.Cells = append(g.Cells, cells...)
+		g.origins = append(g.origins, origin{line: line, lead: lead})
+		g.Height++
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// parseRow decodes the cells of a trimmed line indented by lead blanks,
+// which must be width runes long unless it is the first row.
+func parseRow[T any](l string, lead, width int, first bool, decode func(rune) (T, error)) ([]T, error) {
+	row := []rune(l)
+	if !first && len(row) != width {
+		return nil, fmt.Errorf("expected %d cells, got %d", width, len(row))
+	}
+	cells := make([]T, 0, len(row))
+	for x, c := range row {
+		v, err := decode(c)
+		if err != nil {
+			return nil, &ParseError{Column: lead + x + 1, Text: string(c), Err: err}
+		}
+		cells = append(cells, v)
+	}
+	return cells, nil
+}
+
+// Source returns the line and column of the input ParseGrid read the cell
+// at p from, or zeros for a grid that was not parsed.
+func (g *Grid[T]) Source(p Point) (int, int) {
+	if p.Y < 0 || p.Y >= len(g.origins) {
+		return 0, 0
+	}
+	o := g.origins[p.Y]
+	return o.line, o.lead + p.X + 1
+}
+
+func (g *Grid[T]) InBounds(p Point) bool {
+	return p.X >= 0 && p.X < g.Width && p.Y >= 0 && p.Y < g.Height
+}
+
+// Get returns the cell at p, or the zero value when p is out of bounds.
+func (g *Grid[T]) Get(p Point) T {
+	v, _ := g.Lookup(p)
+	return v
+}
+
+func (g *Grid[T]) Lookup(p Point) (T, bool) {
+	if !g.InBounds(p) {
+		var zero T
+		return zero, false
+	}
+	return g.Cells[p.Y*g.Width+p.X], true
+}
+
+func (g *Grid[T]) Set(p Point, v T) {
+	if !g.InBounds(p) {
+		panic(fmt.Sprintf("point %s out of %dx%d grid", p, g.Width, g.Height))
+	}
+	g.Cells[p.Y*g.Width+p.X] = v
+}
+
+// Each calls f for every cell in row-major order.
+func (g *Grid[T]) Each(f func(p Point, v T)) {
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			f(Point{X: x, Y: y}, g.Cells[y*g.Width+x])
+		}
+	}
+}
+
+func (g *Grid[T]) Row(y int) []T {
+	row := make([]T, g.Width)
+	copy(row, g.Cells[y*g.Width:(y+1)*g.Width])
+	return row
+}
+
+func (g *Grid[T]) Column(x int) []T {
+	col := make([]T, g.Height)
+	for y := 0; y < g.Height; y++ {
+		col[y] = g.Cells[y*g.Width+x]
+	}
+	return col
+}
+
+// EachNeighbor4 calls f for the orthogonal neighbors of p that are inside
+// the grid, clockwise starting from the one above.
+func (g *Grid[T]) EachNeighbor4(p Point, f func(n Point, v T)) {
+	g.each(p.Neighbors4(), f)
+}
+
+// EachNeighbor8 calls f for the orthogonal and diagonal neighbors of p that
+// are inside the grid, clockwise starting from the one above.
+func (g *Grid[T]) EachNeighbor8(p Point, f func(n Point, v T)) {
+	g.each(p.Neighbors8(), f)
+}
+
+func (g *Grid[T]) each(points []Point, f func(n Point, v T)) {
+	for _, n := range points {
+		if v, ok := g.Lookup(n); ok {
+			f(n, v)
+		}
+	}
+}
+
+func (g *Grid[T]) Clone() *Grid[T] {
+	c := NewGrid[T](g.Width, g.Height)
+	copy(c.Cells, g.Cells)
+	c.origins = append([]origin(nil), g.origins...)
+	return c
+}
+
+// Transpose mirrors the grid over its main diagonal.
+func (g *Grid[T]) Transpose() *Grid[T] {
+	t := NewGrid[T](g.Height, g.Width)
+	g.Each(func(p Point, v T) {
+		t.Set(Point{X: p.Y, Y: p.X}, v)
+	})
+	return t
+}
+
+func (g *Grid[T]) RotateClockwise() *Grid[T] {
+	r := NewGrid[T](g.Height, g.Width)
+	g.Each(func(p Point, v T) {
+		r.Set(Point{X: g.Height - 1 - p.Y, Y: p.X}, v)
+	})
+	return r
+}
+
+func (g *Grid[T]) RotateCounterClockwise() *Grid[T] {
+	r := NewGrid[T](g.Height, g.Width)
+	g.Each(func(p Point, v T) {
+		r.Set(Point{X: p.Y, Y: g.Width - 1 - p.X}, v)
+	})
+	return r
+}
+
+// Render writes the grid back as text, one line per row.
+func (g *Grid[T]) Render(encode func(T) string) string {
+	sb := strings.Builder{}
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			sb.WriteString(encode(g.Cells[y*g.Width+x]))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,140 @@
+package advent
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func runeCell(r rune) (rune, error) {
+	return r, nil
+}
+
+func runeString(r rune) string {
+	return string(r)
+}
+
+func TestParseGrid(t *testing.T) {
+	g, err := ParseGrid(strings.NewReader(`
+abc
+def
+`), Strict, runeCell)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, g.Width)
+	assert.Equal(t, 2, g.Height)
+	assert.Equal(t, 'a', g.Get(Point{X: 0, Y: 0}))
+	assert.Equal(t, 'f', g.Get(Point{X: 2, Y: 1}))
+	assert.Equal(t, rune(0), g.Get(Point{X: 3, Y: 1}))
+	assert.Equal(t, "abc\ndef\n", g.Render(runeString))
+}
+
+func TestParseGridErrors(t *testing.T) {
+	_, err := ParseGrid(strings.NewReader("abc\nde\n"), Strict, runeCell)
+	assert.EqualError(t, err, "line 2: expected 3 cells, got 2")
+
+	_, err = ParseGrid(strings.NewReader("12\n3x\n"), Strict, func(r rune) (int, error) {
+		return strconv.Atoi(string(r))
+	})
+	assert.ErrorContains(t, err, "line 2, column 2")
+
+	_, err = ParseGrid(strings.NewReader("123\n  4x6\n"), Strict, func(r rune) (int, error) {
+		return strconv.Atoi(string(r))
+	})
+	assert.ErrorContains(t, err, "line 2, column 4")
+}
+
+func TestGridSource(t *testing.T) {
+	g, err := ParseGrid(strings.NewReader("\nabc\n\n  def\n"), Strict, runeCell)
+	assert.Nil(t, err)
+
+	line, column := g.Source(Point{X: 1, Y: 0})
+	assert.Equal(t, []int{2, 2}, []int{line, column})
+	line, column = g.Source(Point{X: 2, Y: 1})
+	assert.Equal(t, []int{4, 5}, []int{line, column})
+	line, column = g.Clone().Source(Point{X: 0, Y: 1})
+	assert.Equal(t, []int{4, 3}, []int{line, column})
+
+	line, column = NewGrid[rune](2, 2).Source(Point{X: 0, Y: 0})
+	assert.Equal(t, []int{0, 0}, []int{line, column})
+}
+
+func TestParseGridLenient(t *testing.T) {
+	g, err := ParseGrid(strings.NewReader("abc\nde\nfgh\n"), Lenient, runeCell)
+	assert.Nil(t, err)
+	assert.Equal(t, "abc\nfgh\n", g.Render(runeString))
+
+	ints, err := ParseGrid(strings.NewReader("12\n3x\n45\n"), Lenient, func(r rune) (int, error) {
+		return strconv.Atoi(string(r))
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 4, 5}, ints.Cells)
+}
+
+func TestGridBounds(t *testing.T) {
+	g := NewGrid[int](2, 3)
+	assert.True(t, g.InBounds(Point{X: 1, Y: 2}))
+	assert.False(t, g.InBounds(Point{X: 2, Y: 0}))
+	assert.False(t, g.InBounds(Point{X: 0, Y: -1}))
+
+	_, ok := g.Lookup(Point{X: 0, Y: 3})
+	assert.False(t, ok)
+
+	g.Set(Point{X: 1, Y: 2}, 7)
+	v, ok := g.Lookup(Point{X: 1, Y: 2})
+	assert.True(t, ok)
+	assert.Equal(t, 7, v)
+
+	assert.Panics(t, func() { g.Set(Point{X: 2, Y: 2}, 1) })
+}
+
+func TestGridRowsAndColumns(t *testing.T) {
+	g, err := ParseGrid(strings.NewReader("abc\ndef"), Strict, runeCell)
+	assert.Nil(t, err)
+	assert.Equal(t, []rune("def"), g.Row(1))
+	assert.Equal(t, []rune("be"), g.Column(1))
+
+	visited := ""
+	g.Each(func(p Point, v rune) {
+		visited += string(v)
+	})
+	assert.Equal(t, "abcdef", visited)
+}
+
+func TestGridNeighbors(t *testing.T) {
+	g, err := ParseGrid(strings.NewReader("abc\ndef\nghi"), Strict, runeCell)
+	assert.Nil(t, err)
+
+	n4 := ""
+	g.EachNeighbor4(Point{X: 1, Y: 1}, func(p Point, v rune) {
+		n4 += string(v)
+	})
+	assert.Equal(t, "bfhd", n4)
+
+	n8 := ""
+	g.EachNeighbor8(Point{X: 1, Y: 1}, func(p Point, v rune) {
+		n8 += string(v)
+	})
+	assert.Equal(t, "bcfihgda", n8)
+
+	corner := []string{}
+	g.EachNeighbor8(Point{X: 0, Y: 0}, func(p Point, v rune) {
+		corner = append(corner, fmt.Sprintf("%s%c", p, v))
+	})
+	assert.Equal(t, []string{"(1,0)b", "(1,1)e", "(0,1)d"}, corner)
+}
+
+func TestGridTransformations(t *testing.T) {
+	g, err := ParseGrid(strings.NewReader("abc\ndef"), Strict, runeCell)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "ad\nbe\ncf\n", g.Transpose().Render(runeString))
+	assert.Equal(t, "da\neb\nfc\n", g.RotateClockwise().Render(runeString))
+	assert.Equal(t, "cf\nbe\nad\n", g.RotateCounterClockwise().Render(runeString))
+
+	c := g.Clone()
+	c.Set(Point{X: 0, Y: 0}, 'z')
+	assert.Equal(t, 'a', g.Get(Point{X: 0, Y: 0}))
+}
@@ -0,0 +1,139 @@
+package advent
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"sync/atomic"
+)
+
+// Level is how much the solvers tell about what they are doing. Messages
+// of a level are written when the verbosity is at least that level.
+type Level int32
+
+const (
+	Quiet Level = iota
+	Warn
+	Info
+	Debug
+)
+
+// VerboseEnv is the environment variable holding the initial verbosity,
+// as a number or a level name.
+const VerboseEnv = "ADVENT_VERBOSE"
+
+var (
+	verbosity atomic.Int32
+	logger    = log.New(os.Stderr, "", 0)
+)
+
+func init() {
+	if v := os.Getenv(VerboseEnv); v != "" {
+		l, err := ParseLevel(v)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", VerboseEnv, err)
+			return
+		}
+		SetVerbosity(l)
+	}
+}
+
+func (l Level) String() string {
+	switch l {
+	case Quiet:
+		return "quiet"
+	case Warn:
+		return "warn"
+	case Info:
+		return "info"
+	case Debug:
+		return "debug"
+	}
+	return strconv.Itoa(int(l))
+}
+
+func ParseLevel(s string) (Level, error) {
+	for l := Quiet; l <= Debug; l++ {
+		if s == l.String() {
+			return l, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < 0 {
+		return Quiet, fmt.Errorf("invalid log level: %s", s)
+	}
+	return Level(v), nil
+}
+
+func SetVerbosity(l Level) {
+	verbosity.Store(int32(l))
+}
+
+func Verbosity() Level {
+	return Level(verbosity.Load())
+}
+
+// SetLogOutput changes where the messages go, os.Stderr by default.
+func SetLogOutput(w io.Writer) {
+	logger.SetOutput(w)
+}
+
+// Enabled tells whether messages of level l are written, for callers that
+// need some work to build them.
+func Enabled(l Level) bool {
+	return l != Quiet && Verbosity() >= l
+}
+
+func logf(l Level, format string, args ...any) {
+	if Enabled(l) {
+		logger.Printf(format, args...)
+	}
+}
+
+func Warnf(format string, args ...any) {
+	logf(Warn, format, args...)
+}
+
+func Infof(format string, args ...any) {
+	logf(Info, format, args...)
+}
+
+func Debugf(format string, args ...any) {
+	logf(Debug, format, args...)
+}
+
+// VerbosityFlag is a flag.Value for the verbosity: a bare -v raises it by
+// one level, so -v -v means Info, and -v=debug or -v=3 set it directly.
+func VerbosityFlag() flag.Value {
+	return verbosityFlag{}
+}
+
+type verbosityFlag struct{}
+
+func (f verbosityFlag) String() string {
+	return Verbosity().String()
+}
+
+func (f verbosityFlag) Set(s string) error {
+	switch s {
+	case "true":
+		SetVerbosity(Verbosity() + 1)
+		return nil
+	case "false":
+		SetVerbosity(Quiet)
+		return nil
+	}
+	l, err := ParseLevel(s)
+	if err != nil {
+		return err
+	}
+	SetVerbosity(l)
+	return nil
+}
+
+func (f verbosityFlag) IsBoolFlag() bool {
+	return true
+}
@@ -0,0 +1,64 @@
+package advent
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogLevels(t *testing.T) {
+	prev := Verbosity()
+	defer SetVerbosity(prev)
+	buf := &bytes.Buffer{}
+	SetLogOutput(buf)
+	defer SetLogOutput(os.Stderr)
+
+	SetVerbosity(Quiet)
+	Warnf("warn %d", 1)
+	Debugf("debug %d", 1)
+	assert.Equal(t, "", buf.String())
+	assert.False(t, Enabled(Quiet))
+
+	SetVerbosity(Info)
+	Warnf("warn %d", 2)
+	Infof("info %d", 2)
+	Debugf("debug %d", 2)
+	assert.Equal(t, "warn 2\ninfo 2\n", buf.String())
+	assert.True(t, Enabled(Info))
+	assert.False(t, Enabled(Debug))
+}
+
+func TestParseLevel(t *testing.T) {
+	for s, l := range map[string]Level{"quiet": Quiet, "warn": Warn, "debug": Debug, "2": Info, "7": Level(7)} {
+		v, err := ParseLevel(s)
+		assert.Nil(t, err, s)
+		assert.Equal(t, l, v, s)
+	}
+	_, err := ParseLevel("loud")
+	assert.EqualError(t, err, "invalid log level: loud")
+	_, err = ParseLevel("-1")
+	assert.EqualError(t, err, "invalid log level: -1")
+}
+
+func TestVerbosityFlag(t *testing.T) {
+	prev := Verbosity()
+	defer SetVerbosity(prev)
+
+	parse := func(args ...string) Level {
+		SetVerbosity(Quiet)
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.Var(VerbosityFlag(), "v", "verbosity")
+		assert.Nil(t, fs.Parse(args))
+		return Verbosity()
+	}
+
+	assert.Equal(t, Quiet, parse())
+	assert.Equal(t, Warn, parse("-v"))
+	assert.Equal(t, Info, parse("-v", "-v"))
+	assert.Equal(t, Debug, parse("-v=debug"))
+	assert.Equal(t, Info, parse("-v=2"))
+	assert.Equal(t, Quiet, parse("-v=debug", "-v=false"))
+}
@@ -0,0 +1,129 @@
+package advent
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseMode decides what a parser does with input it cannot read.
+type ParseMode int
+
+const (
+	// Lenient skips the lines that cannot be read.
+	Lenient ParseMode = iota
+	// Strict stops at the first line that cannot be read.
+	Strict
+)
+
+// ParseError reports where a puzzle input could not be read. Line and
+// Column start at one, zero meaning unknown; File is usually filled in by
+// whoever opened the input.
+type ParseError struct {
+	File   string
+	Line   int
+	Column int
+	Text   string
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	location := []string{}
+	if e.Line > 0 {
+		location = append(location, fmt.Sprintf("line %d", e.Line))
+	}
+	if e.Column > 0 {
+		location = append(location, fmt.Sprintf("column %d", e.Column))
+	}
+	parts := []string{}
+	if e.File != "" {
+		parts = append(parts, e.File)
+	}
+	if len(location) > 0 {
+		parts = append(parts, strings.Join(location, ", "))
+	}
+	if e.Text != "" {
+		parts = append(parts, fmt.Sprintf("parsing %q", e.Text))
+	}
+	if e.Err != nil {
+		parts = append(parts, e.Err.Error())
+	}
+	return strings.Join(parts, ": ")
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// AtLine places err on a line of the input. A ParseError coming from a
+// parser that only saw that line keeps its column and text; any other error
+// becomes a ParseError for the whole line.
+func AtLine(err error, line int, text string) error {
+	var pe *ParseError
+	if errors.As(err, &pe) && pe.Line == 0 {
+		c := *pe
+		c.Line = line
+		return &c
+	}
+	return &ParseError{Line: line, Text: text, Err: err}
+}
+
+// Offset shifts the column of a ParseError found by a parser that only saw
+// the part of a line starting at column.
+func Offset(err error, column int) error {
+	var pe *ParseError
+	if errors.As(err, &pe) && pe.Column > 0 {
+		c := *pe
+		c.Column += column - 1
+		return &c
+	}
+	return err
+}
+
+// ParseLines calls parse for every non blank line of input. Its errors are
+// turned into ParseErrors for that line, which are skipped in Lenient mode
+// and returned in Strict mode.
+func ParseLines(input io.Reader, mode ParseMode, parse func(l string) error) error {
+	s := bufio.NewScanner(input)
+	s.Buffer(nil, 1024*1024)
+	line := 0
+	for s.Scan() {
+		line++
+		l := s.Text()
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		if err := parse(l); err != nil && mode == Strict {
+			return AtLine(err, line, l)
+		}
+	}
+	return s.Err()
+}
+
+// Field is a space separated word of a line and the column it starts at.
+type Field struct {
+	Text   string
+	Column int
+}
+
+// Fields splits s around runs of spaces and tabs like strings.Fields,
+// keeping the column of every field.
+func Fields(s string) []Field {
+	fields := []Field{}
+	start := -1
+	for i := 0; i <= len(s); i++ {
+		if i < len(s) && s[i] != ' ' && s[i] != '\t' {
+			if start == -1 {
+				start = i
+			}
+			continue
+		}
+		if start != -1 {
+			fields = append(fields, Field{Text: s[start:i], Column: start + 1})
+			start = -1
+		}
+	}
+	return fields
+}
@@ -0,0 +1,64 @@
+package advent
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseError(t *testing.T) {
+	err := &ParseError{File: "day1/input.txt", Line: 3, Column: 7, Text: "x1", Err: errors.New("invalid number")}
+	assert.EqualError(t, err, `day1/input.txt: line 3, column 7: parsing "x1": invalid number`)
+
+	assert.EqualError(t, &ParseError{Line: 2, Err: errors.New("bad")}, "line 2: bad")
+	assert.EqualError(t, &ParseError{Column: 4, Text: "?"}, `column 4: parsing "?"`)
+
+	cause := errors.New("cause")
+	assert.ErrorIs(t, &ParseError{Line: 1, Err: cause}, cause)
+}
+
+func TestAtLineAndOffset(t *testing.T) {
+	inner := &ParseError{Column: 2, Text: "x", Err: errors.New("bad")}
+	err := AtLine(Offset(inner, 5), 4, "abcdxyz")
+	assert.EqualError(t, err, `line 4, column 6: parsing "x": bad`)
+	assert.Equal(t, 2, inner.Column)
+
+	err = AtLine(errors.New("bad"), 4, "abcd")
+	assert.EqualError(t, err, `line 4: parsing "abcd": bad`)
+
+	plain := errors.New("plain")
+	assert.Equal(t, plain, Offset(plain, 3))
+}
+
+func TestParseLines(t *testing.T) {
+	input := "1\n\nx\n3\n"
+	parse := func(seen *[]string) func(string) error {
+		return func(l string) error {
+			if l == "x" {
+				return errors.New("not a number")
+			}
+			*seen = append(*seen, l)
+			return nil
+		}
+	}
+
+	seen := []string{}
+	err := ParseLines(strings.NewReader(input), Lenient, parse(&seen))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "3"}, seen)
+
+	seen = []string{}
+	err = ParseLines(strings.NewReader(input), Strict, parse(&seen))
+	assert.EqualError(t, err, `line 3: parsing "x": not a number`)
+	assert.Equal(t, []string{"1"}, seen)
+}
+
+func TestFields(t *testing.T) {
+	assert.Equal(t, []Field{
+		{Text: "79", Column: 2},
+		{Text: "14", Column: 6},
+	}, Fields(" 79 \t14"))
+	assert.Equal(t, []Field{}, Fields("   "))
+}
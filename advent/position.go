@@ -1,6 +1,10 @@
 package advent
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 type Point struct {
 	X int
@@ -10,3 +14,122 @@ type Point struct {
 func (p Point) String() string {
 	return fmt.Sprintf("(%d,%d)", p.X, p.Y)
 }
+
+// ParsePoint reads a point in the "(x,y)" format produced by String.
+func ParsePoint(s string) (Point, error) {
+	v := strings.TrimSpace(s)
+	if !strings.HasPrefix(v, "(") || !strings.HasSuffix(v, ")") {
+		return Point{}, fmt.Errorf("invalid point: %s", s)
+	}
+	parts := strings.Split(v[1:len(v)-1], ",")
+	if len(parts) != 2 {
+		return Point{}, fmt.Errorf("invalid point: %s", s)
+	}
+	x, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return Point{}, fmt.Errorf("invalid point x: %s", s)
+	}
+	y, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return Point{}, fmt.Errorf("invalid point y: %s", s)
+	}
+	return Point{X: x, Y: y}, nil
+}
+
+func (p Point) Add(q Point) Point {
+	return Point{X: p.X + q.X, Y: p.Y + q.Y}
+}
+
+func (p Point) Sub(q Point) Point {
+	return Point{X: p.X - q.X, Y: p.Y - q.Y}
+}
+
+func (p Point) Scale(k int) Point {
+	return Point{X: p.X * k, Y: p.Y * k}
+}
+
+func (p Point) Manhattan(q Point) int {
+	return abs(p.X-q.X) + abs(p.Y-q.Y)
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func (p Point) Move(d Direction) Point {
+	return p.Add(d.Delta())
+}
+
+var (
+	offsets4 = []Point{{X: 0, Y: -1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 0}}
+	offsets8 = []Point{
+		{X: 0, Y: -1}, {X: 1, Y: -1}, {X: 1, Y: 0}, {X: 1, Y: 1},
+		{X: 0, Y: 1}, {X: -1, Y: 1}, {X: -1, Y: 0}, {X: -1, Y: -1},
+	}
+)
+
+// Neighbors4 returns the orthogonal neighbors of p, clockwise starting from
+// the one above.
+func (p Point) Neighbors4() []Point {
+	return p.offset(offsets4)
+}
+
+// Neighbors8 returns the orthogonal and diagonal neighbors of p, clockwise
+// starting from the one above.
+func (p Point) Neighbors8() []Point {
+	return p.offset(offsets8)
+}
+
+func (p Point) offset(offsets []Point) []Point {
+	result := make([]Point, len(offsets))
+	for i, o := range offsets {
+		result[i] = p.Add(o)
+	}
+	return result
+}
+
+// Direction is one of the four orthogonal directions, with Y growing
+// downwards as in the puzzle maps.
+type Direction int
+
+const (
+	Up Direction = iota
+	Right
+	Down
+	Left
+)
+
+var Directions = []Direction{Up, Right, Down, Left}
+
+func (d Direction) Delta() Point {
+	return offsets4[d]
+}
+
+func (d Direction) TurnLeft() Direction {
+	return (d + 3) % 4
+}
+
+func (d Direction) TurnRight() Direction {
+	return (d + 1) % 4
+}
+
+func (d Direction) Reverse() Direction {
+	return (d + 2) % 4
+}
+
+func (d Direction) String() string {
+	switch d {
+	case Up:
+		return "up"
+	case Right:
+		return "right"
+	case Down:
+		return "down"
+	case Left:
+		return "left"
+	}
+	return fmt.Sprintf("Direction(%d)", int(d))
+}
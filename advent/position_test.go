@@ -0,0 +1,63 @@
+package advent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPointArithmetic(t *testing.T) {
+	p := Point{X: 3, Y: -2}
+	q := Point{X: 1, Y: 4}
+
+	assert.Equal(t, Point{X: 4, Y: 2}, p.Add(q))
+	assert.Equal(t, Point{X: 2, Y: -6}, p.Sub(q))
+	assert.Equal(t, Point{X: 9, Y: -6}, p.Scale(3))
+	assert.Equal(t, 8, p.Manhattan(q))
+	assert.Equal(t, 8, q.Manhattan(p))
+}
+
+func TestParsePoint(t *testing.T) {
+	p, err := ParsePoint("(12,-3)")
+	assert.Nil(t, err)
+	assert.Equal(t, Point{X: 12, Y: -3}, p)
+
+	p, err = ParsePoint(" ( 4, 5 ) ")
+	assert.Nil(t, err)
+	assert.Equal(t, Point{X: 4, Y: 5}, p)
+
+	p, err = ParsePoint(Point{X: 7, Y: 8}.String())
+	assert.Nil(t, err)
+	assert.Equal(t, Point{X: 7, Y: 8}, p)
+
+	for _, s := range []string{"1,2", "(1,2", "(1)", "(a,2)", "(1,b)", "(1,2,3)"} {
+		_, err := ParsePoint(s)
+		assert.NotNil(t, err, s)
+	}
+}
+
+func TestPointNeighbors(t *testing.T) {
+	p := Point{X: 1, Y: 1}
+	assert.Equal(t, []Point{{X: 1, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 2}, {X: 0, Y: 1}}, p.Neighbors4())
+	assert.Equal(t, []Point{
+		{X: 1, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, {X: 2, Y: 2},
+		{X: 1, Y: 2}, {X: 0, Y: 2}, {X: 0, Y: 1}, {X: 0, Y: 0},
+	}, p.Neighbors8())
+}
+
+func TestDirection(t *testing.T) {
+	p := Point{X: 0, Y: 0}
+	assert.Equal(t, Point{X: 0, Y: -1}, p.Move(Up))
+	assert.Equal(t, Point{X: 1, Y: 0}, p.Move(Right))
+	assert.Equal(t, Point{X: 0, Y: 1}, p.Move(Down))
+	assert.Equal(t, Point{X: -1, Y: 0}, p.Move(Left))
+
+	assert.Equal(t, Left, Up.TurnLeft())
+	assert.Equal(t, Right, Up.TurnRight())
+	assert.Equal(t, Up, Left.TurnRight())
+	assert.Equal(t, Down, Left.TurnLeft())
+	assert.Equal(t, Down, Up.Reverse())
+	assert.Equal(t, Right, Left.Reverse())
+
+	assert.Equal(t, "left", Left.String())
+}
@@ -0,0 +1,101 @@
+package advent
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Result is the answer of a single part of a day, as reported by the runner.
+// Duration is the time spent solving the part and ParseDuration the time
+// the Solver spent parsing the input, the same for both parts of a day, as
+// some solvers do most of their work while parsing.
+type Result struct {
+	Day           int           `json:"day"`
+	Part          int           `json:"part"`
+	Answer        int           `json:"answer"`
+	Duration      time.Duration `json:"duration_ns"`
+	ParseDuration time.Duration `json:"parse_duration_ns"`
+	InputHash     string        `json:"input_hash"`
+}
+
+func InputHash(input []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(input))
+}
+
+type ResultWriter interface {
+	Write(r Result) error
+	Flush() error
+}
+
+// NewResultWriter returns a writer for one of the text, json or csv formats.
+// The json format emits one object per line.
+func NewResultWriter(format string, w io.Writer) (ResultWriter, error) {
+	switch format {
+	case "text":
+		return &textResultWriter{w: w}, nil
+	case "json":
+		return &jsonResultWriter{enc: json.NewEncoder(w)}, nil
+	case "csv":
+		return &csvResultWriter{w: csv.NewWriter(w)}, nil
+	}
+	return nil, fmt.Errorf("unknown result format: %s", format)
+}
+
+type textResultWriter struct {
+	w io.Writer
+}
+
+func (t *textResultWriter) Write(r Result) error {
+	_, err := fmt.Fprintf(t.w, "Day %d Part %d: %d (%s, parsed in %s)\n", r.Day, r.Part, r.Answer, r.Duration, r.ParseDuration)
+	return err
+}
+
+func (t *textResultWriter) Flush() error {
+	return nil
+}
+
+type jsonResultWriter struct {
+	enc *json.Encoder
+}
+
+func (j *jsonResultWriter) Write(r Result) error {
+	return j.enc.Encode(r)
+}
+
+func (j *jsonResultWriter) Flush() error {
+	return nil
+}
+
+var csvHeader = []string{"day", "part", "answer", "duration_ns", "parse_duration_ns", "input_hash"}
+
+type csvResultWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (c *csvResultWriter) Write(r Result) error {
+	if !c.header {
+		if err := c.w.Write(csvHeader); err != nil {
+			return err
+		}
+		c.header = true
+	}
+	return c.w.Write([]string{
+		strconv.Itoa(r.Day),
+		strconv.Itoa(r.Part),
+		strconv.Itoa(r.Answer),
+		strconv.FormatInt(int64(r.Duration), 10),
+		strconv.FormatInt(int64(r.ParseDuration), 10),
+		r.InputHash,
+	})
+}
+
+func (c *csvResultWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
@@ -0,0 +1,51 @@
+package advent
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var results = []Result{
+	{Day: 1, Part: 1, Answer: 142, Duration: 1500 * time.Microsecond, ParseDuration: 300 * time.Microsecond, InputHash: "abc"},
+	{Day: 1, Part: 2, Answer: 281, Duration: 2 * time.Millisecond, ParseDuration: 300 * time.Microsecond, InputHash: "abc"},
+}
+
+func writeResults(t *testing.T, format string) string {
+	b := &bytes.Buffer{}
+	w, err := NewResultWriter(format, b)
+	assert.Nil(t, err)
+	for _, r := range results {
+		assert.Nil(t, w.Write(r))
+	}
+	assert.Nil(t, w.Flush())
+	return b.String()
+}
+
+func TestTextResultWriter(t *testing.T) {
+	assert.Equal(t, "Day 1 Part 1: 142 (1.5ms, parsed in 300µs)\nDay 1 Part 2: 281 (2ms, parsed in 300µs)\n", writeResults(t, "text"))
+}
+
+func TestJSONResultWriter(t *testing.T) {
+	assert.Equal(t, `{"day":1,"part":1,"answer":142,"duration_ns":1500000,"parse_duration_ns":300000,"input_hash":"abc"}
+{"day":1,"part":2,"answer":281,"duration_ns":2000000,"parse_duration_ns":300000,"input_hash":"abc"}
+`, writeResults(t, "json"))
+}
+
+func TestCSVResultWriter(t *testing.T) {
+	assert.Equal(t, `day,part,answer,duration_ns,parse_duration_ns,input_hash
+1,1,142,1500000,300000,abc
+1,2,281,2000000,300000,abc
+`, writeResults(t, "csv"))
+}
+
+func TestUnknownResultFormat(t *testing.T) {
+	_, err := NewResultWriter("xml", &bytes.Buffer{})
+	assert.NotNil(t, err)
+}
+
+func TestInputHash(t *testing.T) {
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", InputHash([]byte{}))
+}
@@ -0,0 +1,121 @@
+// Package search implements generic graph searches over any comparable state
+// type, leaving it to the caller to describe how states connect.
+package search
+
+import "container/heap"
+
+type Edge[S comparable] struct {
+	To   S
+	Cost int
+}
+
+// Result holds the cost of the path found and the states along it, from
+// the start state to the goal, both included.
+type Result[S comparable] struct {
+	Cost int
+	Path []S
+}
+
+// BFS finds the path with the fewest steps from any of the start states to a
+// state accepted by goal.
+func BFS[S comparable](start []S, neighbors func(S) []S, goal func(S) bool) (Result[S], bool) {
+	prev := map[S]S{}
+	seen := map[S]bool{}
+	queue := []S{}
+	for _, s := range start {
+		if !seen[s] {
+			seen[s] = true
+			queue = append(queue, s)
+		}
+	}
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		if goal(s) {
+			path := reconstruct(prev, s)
+			return Result[S]{Cost: len(path) - 1, Path: path}, true
+		}
+		for _, n := range neighbors(s) {
+			if seen[n] {
+				continue
+			}
+			seen[n] = true
+			prev[n] = s
+			queue = append(queue, n)
+		}
+	}
+	return Result[S]{}, false
+}
+
+// Dijkstra finds the cheapest path from any of the start states to a state
+// accepted by goal. Edge costs must not be negative.
+func Dijkstra[S comparable](start []S, neighbors func(S) []Edge[S], goal func(S) bool) (Result[S], bool) {
+	return AStar(start, neighbors, goal, func(S) int { return 0 })
+}
+
+// AStar is Dijkstra guided by heuristic, which must never overestimate the
+// remaining cost to reach the goal.
+func AStar[S comparable](start []S, neighbors func(S) []Edge[S], goal func(S) bool, heuristic func(S) int) (Result[S], bool) {
+	cost := map[S]int{}
+	prev := map[S]S{}
+	q := &queue[S]{}
+	for _, s := range start {
+		cost[s] = 0
+		heap.Push(q, node[S]{state: s, priority: heuristic(s)})
+	}
+
+	for q.Len() > 0 {
+		n := heap.Pop(q).(node[S])
+		c := cost[n.state]
+		if n.priority > c+heuristic(n.state) {
+			// stale entry, the state was reached again for less
+			continue
+		}
+		if goal(n.state) {
+			return Result[S]{Cost: c, Path: reconstruct(prev, n.state)}, true
+		}
+		for _, e := range neighbors(n.state) {
+			nc := c + e.Cost
+			if old, ok := cost[e.To]; ok && old <= nc {
+				continue
+			}
+			cost[e.To] = nc
+			prev[e.To] = n.state
+			heap.Push(q, node[S]{state: e.To, priority: nc + heuristic(e.To)})
+		}
+	}
+	return Result[S]{}, false
+}
+
+func reconstruct[S comparable](prev map[S]S, end S) []S {
+	path := []S{end}
+	for {
+		p, ok := prev[path[len(path)-1]]
+		if !ok {
+			break
+		}
+		path = append(path, p)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+type node[S comparable] struct {
+	state    S
+	priority int
+}
+
+type queue[S comparable] []node[S]
+
+func (q queue[S]) Len() int            { return len(q) }
+func (q queue[S]) Less(i, j int) bool  { return q[i].priority < q[j].priority }
+func (q queue[S]) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *queue[S]) Push(x interface{}) { *q = append(*q, x.(node[S])) }
+func (q *queue[S]) Pop() interface{} {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
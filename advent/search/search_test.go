@@ -0,0 +1,100 @@
+package search
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pedrokiefer/adventofcode-2023/advent"
+	"github.com/stretchr/testify/assert"
+)
+
+func parseMaze(t *testing.T, s string) *advent.Grid[rune] {
+	g, err := advent.ParseGrid(strings.NewReader(s), advent.Strict, func(r rune) (rune, error) {
+		return r, nil
+	})
+	assert.Nil(t, err)
+	return g
+}
+
+func TestBFS(t *testing.T) {
+	g := parseMaze(t, `
+..#.
+.##.
+....`)
+	end := advent.Point{X: 3, Y: 0}
+	neighbors := func(p advent.Point) []advent.Point {
+		result := []advent.Point{}
+		g.EachNeighbor4(p, func(n advent.Point, v rune) {
+			if v != '#' {
+				result = append(result, n)
+			}
+		})
+		return result
+	}
+
+	r, ok := BFS([]advent.Point{{X: 0, Y: 0}}, neighbors, func(p advent.Point) bool { return p == end })
+	assert.True(t, ok)
+	assert.Equal(t, 7, r.Cost)
+	assert.Equal(t, []advent.Point{
+		{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 2}, {X: 1, Y: 2},
+		{X: 2, Y: 2}, {X: 3, Y: 2}, {X: 3, Y: 1}, {X: 3, Y: 0},
+	}, r.Path)
+
+	_, ok = BFS([]advent.Point{{X: 0, Y: 0}}, neighbors, func(p advent.Point) bool { return p == advent.Point{X: 2, Y: 0} })
+	assert.False(t, ok)
+}
+
+func weightedGraph() func(string) []Edge[string] {
+	edges := map[string][]Edge[string]{
+		"a": {{To: "b", Cost: 7}, {To: "c", Cost: 9}, {To: "f", Cost: 14}},
+		"b": {{To: "a", Cost: 7}, {To: "c", Cost: 10}, {To: "d", Cost: 15}},
+		"c": {{To: "a", Cost: 9}, {To: "b", Cost: 10}, {To: "d", Cost: 11}, {To: "f", Cost: 2}},
+		"d": {{To: "b", Cost: 15}, {To: "c", Cost: 11}, {To: "e", Cost: 6}},
+		"e": {{To: "d", Cost: 6}, {To: "f", Cost: 9}},
+		"f": {{To: "a", Cost: 14}, {To: "c", Cost: 2}, {To: "e", Cost: 9}},
+	}
+	return func(s string) []Edge[string] {
+		return edges[s]
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	r, ok := Dijkstra([]string{"a"}, weightedGraph(), func(s string) bool { return s == "e" })
+	assert.True(t, ok)
+	assert.Equal(t, 20, r.Cost)
+	assert.Equal(t, []string{"a", "c", "f", "e"}, r.Path)
+
+	r, ok = Dijkstra([]string{"a", "d"}, weightedGraph(), func(s string) bool { return s == "e" })
+	assert.True(t, ok)
+	assert.Equal(t, 6, r.Cost)
+	assert.Equal(t, []string{"d", "e"}, r.Path)
+
+	_, ok = Dijkstra([]string{"a"}, weightedGraph(), func(s string) bool { return s == "z" })
+	assert.False(t, ok)
+}
+
+func TestAStar(t *testing.T) {
+	g := parseMaze(t, `
+1191
+9191
+1111`)
+	end := advent.Point{X: 3, Y: 0}
+	neighbors := func(p advent.Point) []Edge[advent.Point] {
+		result := []Edge[advent.Point]{}
+		g.EachNeighbor4(p, func(n advent.Point, v rune) {
+			result = append(result, Edge[advent.Point]{To: n, Cost: int(v - '0')})
+		})
+		return result
+	}
+	heuristic := func(p advent.Point) int {
+		return p.Manhattan(end)
+	}
+
+	r, ok := AStar([]advent.Point{{X: 0, Y: 0}}, neighbors, func(p advent.Point) bool { return p == end }, heuristic)
+	assert.True(t, ok)
+	d, _ := Dijkstra([]advent.Point{{X: 0, Y: 0}}, neighbors, func(p advent.Point) bool { return p == end })
+	assert.Equal(t, d.Cost, r.Cost)
+	assert.Equal(t, 7, r.Cost)
+	assert.Equal(t, advent.Point{X: 0, Y: 0}, r.Path[0])
+	assert.Equal(t, end, r.Path[len(r.Path)-1])
+}
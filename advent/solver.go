@@ -0,0 +1,48 @@
+package advent
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+var ErrNotImplemented = errors.New("not implemented")
+
+// Solver parses a day's puzzle input once and answers both of its parts.
+type Solver interface {
+	Parse(input io.ReadCloser) error
+	Part1() (int, error)
+	Part2() (int, error)
+}
+
+type SolverFactory func() Solver
+
+var solvers = map[int]SolverFactory{}
+
+// Register makes the solver for a day available to the runner. It is meant
+// to be called from the init function of each day package.
+func Register(day int, f SolverFactory) {
+	if _, ok := solvers[day]; ok {
+		panic(fmt.Sprintf("solver for day %d already registered", day))
+	}
+	solvers[day] = f
+}
+
+func NewSolver(day int) (Solver, error) {
+	f, ok := solvers[day]
+	if !ok {
+		return nil, fmt.Errorf("no solver registered for day %d", day)
+	}
+	return f(), nil
+}
+
+// Days returns every registered day in ascending order.
+func Days() []int {
+	days := []int{}
+	for d := range solvers {
+		days = append(days, d)
+	}
+	sort.Ints(days)
+	return days
+}
@@ -0,0 +1,178 @@
+package day1
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pedrokiefer/adventofcode-2023/advent"
+)
+
+var ErrNoDigits = errors.New("no digits found")
+
+func InputToIntList(input io.ReadCloser, calibration func(string) (int64, error), mode advent.ParseMode) ([]int64, error) {
+	results := []int64{}
+	defer input.Close()
+	err := advent.ParseLines(input, mode, func(l string) error {
+		c, err := calibration(l)
+		if err != nil {
+			return err
+		}
+		results = append(results, c)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func Sum(l []int64) int64 {
+	var sum int64
+	for _, v := range l {
+		sum += v
+	}
+	return sum
+}
+
+func GetCalibrationValue(s string) (int64, error) {
+	f := FindFirstDigit(s)
+	l := FindLastDigit(s)
+	if f == "" {
+		return -1, ErrNoDigits
+	}
+
+	calString := f + l
+	calValue, err := strconv.ParseInt(calString, 10, 64)
+	if err != nil {
+		return -1, err
+	}
+
+	return calValue, nil
+}
+
+func FindFirstDigit(s string) string {
+	for _, r := range s {
+		if v, ok := DigitValue(r); ok {
+			return strconv.Itoa(v)
+		}
+	}
+	return ""
+}
+
+func FindLastDigit(s string) string {
+	for i := len(s); i > 0; {
+		r, size := utf8.DecodeLastRuneInString(s[:i])
+		if v, ok := DigitValue(r); ok {
+			return strconv.Itoa(v)
+		}
+		i -= size
+	}
+	return ""
+}
+
+// DigitValue returns the numeric value of a decimal digit of any script,
+// that is any rune for which unicode.IsDigit is true. Unicode encodes those
+// in runs of ten going from zero to nine, so the value is the distance to
+// the start of the run.
+func DigitValue(r rune) (int, bool) {
+	if r >= '0' && r <= '9' {
+		return int(r - '0'), true
+	}
+	if !unicode.IsDigit(r) {
+		return 0, false
+	}
+	for _, rg := range unicode.Nd.R16 {
+		if r >= rune(rg.Lo) && r <= rune(rg.Hi) {
+			return int(r-rune(rg.Lo)) % 10, true
+		}
+	}
+	for _, rg := range unicode.Nd.R32 {
+		if r >= rune(rg.Lo) && r <= rune(rg.Hi) {
+			return int(r-rune(rg.Lo)) % 10, true
+		}
+	}
+	return 0, false
+}
+
+func GetCalibrationValue2(s string) (int64, error) {
+	f := FindFirstDigit2(s)
+	l := FindLastDigit2(s)
+	if f == "" {
+		return -1, ErrNoDigits
+	}
+
+	calString := f + l
+	calValue, err := strconv.ParseInt(calString, 10, 64)
+	if err != nil {
+		return -1, err
+	}
+
+	return calValue, nil
+}
+
+func FindFirstDigit2(s string) string {
+	v, ok := english.First(s)
+	if !ok {
+		return ""
+	}
+	return strconv.Itoa(v)
+}
+
+func FindLastDigit2(s string) string {
+	v, ok := english.Last(s)
+	if !ok {
+		return ""
+	}
+	return strconv.Itoa(v)
+}
+
+type Solver struct {
+	sums [2]int64
+	errs [2]error
+}
+
+func init() {
+	advent.Register(1, func() advent.Solver { return &Solver{} })
+}
+
+// Parse computes both parts in a single pass. A line without digits only
+// fails the part whose calibration could not read it, as the example of
+// one part need not be valid for the other.
+func (s *Solver) Parse(input io.ReadCloser) error {
+	defer input.Close()
+	errs := [2]error{}
+	lines := [2]int{}
+	p1, p2, err := Calibrate(input, func(d Diagnostic) {
+		line := 0
+		var pe *advent.ParseError
+		if errors.As(d.Err, &pe) {
+			line = pe.Line
+		}
+		if errs[d.Calibration] == nil || line < lines[d.Calibration] {
+			errs[d.Calibration], lines[d.Calibration] = d.Err, line
+		}
+	})
+	if err != nil {
+		return err
+	}
+	s.sums = [2]int64{p1, p2}
+	s.errs = errs
+	return nil
+}
+
+func (s *Solver) Part1() (int, error) {
+	if s.errs[0] != nil {
+		return 0, s.errs[0]
+	}
+	return int(s.sums[0]), nil
+}
+
+func (s *Solver) Part2() (int, error) {
+	if s.errs[1] != nil {
+		return 0, s.errs[1]
+	}
+	return int(s.sums[1]), nil
+}
@@ -1,10 +1,14 @@
-package main
+package day1
 
 import (
 	"bytes"
+	"fmt"
 	"io"
+	"sort"
+	"strings"
 	"testing"
 
+	"github.com/pedrokiefer/adventofcode-2023/advent"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -16,7 +20,8 @@ a1b2c3d4e5f
 treb7uchet
 `)))
 
-	list := InputToIntList(input, GetCalibrationValue)
+	list, err := InputToIntList(input, GetCalibrationValue, advent.Strict)
+	assert.Nil(t, err)
 
 	assert.Equal(t, []int64{12, 38, 15, 77}, list)
 }
@@ -32,7 +37,8 @@ zoneight234
 7pqrstsixteen
 `)))
 
-	list := InputToIntList(input, GetCalibrationValue2)
+	list, err := InputToIntList(input, GetCalibrationValue2, advent.Strict)
+	assert.Nil(t, err)
 
 	assert.Equal(t, []int64{29, 83, 13, 24, 42, 14, 76}, list)
 }
@@ -68,3 +74,200 @@ func TestGetCalibrationValue2(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, int64(29), v)
 }
+
+func TestFileToIntListMode(t *testing.T) {
+	input := []byte(`1abc2
+pqr3stu8vwx
+abcdef
+treb7uchet
+`)
+
+	list, err := InputToIntList(io.NopCloser(bytes.NewReader(input)), GetCalibrationValue, advent.Lenient)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{12, 38, 77}, list)
+
+	_, err = InputToIntList(io.NopCloser(bytes.NewReader(input)), GetCalibrationValue, advent.Strict)
+	assert.EqualError(t, err, `line 3: parsing "abcdef": no digits found`)
+	assert.ErrorIs(t, err, ErrNoDigits)
+	var pe *advent.ParseError
+	assert.ErrorAs(t, err, &pe)
+	assert.Equal(t, 3, pe.Line)
+}
+
+func TestMatcher(t *testing.T) {
+	m := NewMatcher(English)
+	assert.Equal(t, []int{8, 2, 3}, m.Matches("eightwothree"))
+	assert.Equal(t, []int{2, 1, 3, 4}, m.Matches("xtwone3four"))
+
+	v, ok := m.Last("eightwo")
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+
+	_, ok = m.First("abcdef")
+	assert.False(t, ok)
+
+	// longest word wins when several start at the same position
+	m = NewMatcher(map[string]int{"seven": 7, "seventeen": 17, "teen": 0})
+	assert.Equal(t, []int{17, 0}, m.Matches("seventeen"))
+	c, err := m.CalibrationValue("seventeen")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(170), c)
+}
+
+func TestMatcherLanguages(t *testing.T) {
+	input := []byte(`doistrês7
+umoito
+xquatrocinconove2seis
+`)
+	list, err := InputToIntList(io.NopCloser(bytes.NewReader(input)), NewMatcher(Portuguese).CalibrationValue, advent.Strict)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{27, 18, 46}, list)
+
+	v, err := NewMatcher(Spanish).CalibrationValue("nuevecuatrodos")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(92), v)
+
+	_, err = NewMatcher(Spanish).CalibrationValue("eight")
+	assert.ErrorIs(t, err, ErrNoDigits)
+}
+
+func TestDigitValue(t *testing.T) {
+	for _, tc := range []struct {
+		r     rune
+		value int
+	}{
+		{'7', 7},
+		{'٣', 3}, // Arabic-Indic
+		{'۵', 5}, // Extended Arabic-Indic
+		{'७', 7}, // Devanagari
+		{'０', 0}, // full-width
+		{'９', 9},
+		{'𝟗', 9},          // mathematical bold
+		{'\U0001D7EE', 2}, // mathematical sans-serif bold, after three other runs
+	} {
+		v, ok := DigitValue(tc.r)
+		assert.True(t, ok, string(tc.r))
+		assert.Equal(t, tc.value, v, string(tc.r))
+	}
+
+	for _, r := range []rune{'a', 'Ⅻ', '½', '²', '三'} {
+		_, ok := DigitValue(r)
+		assert.False(t, ok, string(r))
+	}
+}
+
+func TestUnicodeCalibrationValue(t *testing.T) {
+	input := io.NopCloser(bytes.NewReader([]byte(`ação٣مرحبا٧
+日本４語x２
+ñ1ü
+Ⅻ²३abc٠
+`)))
+
+	list, err := InputToIntList(input, GetCalibrationValue, advent.Strict)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{37, 42, 11, 30}, list)
+
+	v, err := GetCalibrationValue2("três٢oneç")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(21), v)
+
+	v, err = NewMatcher(Portuguese).CalibrationValue("éum٩três")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(13), v)
+
+	assert.Equal(t, "", FindFirstDigit("ação"))
+	assert.Equal(t, "", FindLastDigit2("ação"))
+}
+
+func TestCalibrate(t *testing.T) {
+	input := strings.NewReader(`two1nine
+eightwothree
+
+abcone2threexyz
+xtwone3four
+nodigits
+4nineeightseven2
+zoneight234
+7pqrstsixteen
+`)
+
+	diagnostics := []string{}
+	p1, p2, err := Calibrate(input, func(d Diagnostic) {
+		diagnostics = append(diagnostics, fmt.Sprintf("%d %v", d.Calibration, d.Err))
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(11+22+33+42+24+77), p1)
+	assert.Equal(t, int64(281), p2)
+	sort.Strings(diagnostics)
+	assert.Equal(t, []string{
+		`0 line 2: parsing "eightwothree": no digits found`,
+		`0 line 6: parsing "nodigits": no digits found`,
+		`1 line 6: parsing "nodigits": no digits found`,
+	}, diagnostics)
+}
+
+func TestSolverPartDiagnostics(t *testing.T) {
+	s := &Solver{}
+	err := s.Parse(io.NopCloser(strings.NewReader(`two1nine
+eightwothree
+abcone2threexyz
+xtwone3four
+4nineeightseven2
+zoneight234
+7pqrstsixteen
+`)))
+	assert.Nil(t, err)
+
+	_, err = s.Part1()
+	assert.EqualError(t, err, `line 2: parsing "eightwothree": no digits found`)
+	var pe *advent.ParseError
+	assert.ErrorAs(t, err, &pe)
+
+	v, err := s.Part2()
+	assert.Nil(t, err)
+	assert.Equal(t, 281, v)
+}
+
+// lineReader generates n calibration lines without keeping them in memory.
+type lineReader struct {
+	n    int
+	line int
+	buf  []byte
+}
+
+func (r *lineReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.line == r.n {
+			return 0, io.EOF
+		}
+		r.buf = []byte(fmt.Sprintf("a%dbtwo%dc\n", r.line%10, (r.line+3)%10))
+		r.line++
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func TestSumCalibrationsStream(t *testing.T) {
+	n := 10*streamBatch + 7
+	sums, err := SumCalibrations(&lineReader{n: n}, func(d Diagnostic) {
+		t.Errorf("unexpected diagnostic: %v", d.Err)
+	}, GetCalibrationValue, GetCalibrationValue2, NewMatcher(English).CalibrationValue)
+	assert.Nil(t, err)
+
+	want1, want2 := int64(0), int64(0)
+	for i := 0; i < n; i++ {
+		want1 += int64(i%10*10 + (i+3)%10)
+		want2 += int64(i%10*10 + (i+3)%10)
+	}
+	assert.Equal(t, []int64{want1, want2, want2}, sums)
+}
+
+func BenchmarkCalibrate(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _, err := Calibrate(&lineReader{n: 100000}, nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package day1
+
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
+// Vocabularies of spelled numbers for NewMatcher.
+var (
+	English = map[string]int{
+		"one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+		"six": 6, "seven": 7, "eight": 8, "nine": 9,
+	}
+	Portuguese = map[string]int{
+		"um": 1, "dois": 2, "três": 3, "quatro": 4, "cinco": 5,
+		"seis": 6, "sete": 7, "oito": 8, "nove": 9,
+	}
+	Spanish = map[string]int{
+		"uno": 1, "dos": 2, "tres": 3, "cuatro": 4, "cinco": 5,
+		"seis": 6, "siete": 7, "ocho": 8, "nueve": 9,
+	}
+)
+
+var english = NewMatcher(English)
+
+// Matcher finds digits and spelled numbers in a line. Its vocabulary is
+// kept in a trie, so every position of the line is checked against all
+// the words at once, and words may overlap as in "eightwo".
+type Matcher struct {
+	root *node
+}
+
+type node struct {
+	next  map[byte]*node
+	value int
+	word  bool
+}
+
+func NewMatcher(words map[string]int) *Matcher {
+	root := &node{next: map[byte]*node{}}
+	for w, v := range words {
+		n := root
+		for i := 0; i < len(w); i++ {
+			c, ok := n.next[w[i]]
+			if !ok {
+				c = &node{next: map[byte]*node{}}
+				n.next[w[i]] = c
+			}
+			n = c
+		}
+		n.value = v
+		n.word = true
+	}
+	return &Matcher{root: root}
+}
+
+// At returns the value of the digit or the longest word starting at byte
+// i of s, which must be the start of a rune.
+func (m *Matcher) At(s string, i int) (int, bool) {
+	r, _ := utf8.DecodeRuneInString(s[i:])
+	if v, ok := DigitValue(r); ok {
+		return v, true
+	}
+	value, found := 0, false
+	n := m.root
+	for j := i; j < len(s); j++ {
+		n = n.next[s[j]]
+		if n == nil {
+			break
+		}
+		if n.word {
+			value, found = n.value, true
+		}
+	}
+	return value, found
+}
+
+func (m *Matcher) First(s string) (int, bool) {
+	for i := range s {
+		if v, ok := m.At(s, i); ok {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+func (m *Matcher) Last(s string) (int, bool) {
+	for i := len(s); i > 0; {
+		_, size := utf8.DecodeLastRuneInString(s[:i])
+		i -= size
+		if v, ok := m.At(s, i); ok {
+			return v, true
+		}
+	}
+	return 0, false
+}
+
+// Matches returns the values of every digit and word of s, in the order
+// they start.
+func (m *Matcher) Matches(s string) []int {
+	result := []int{}
+	for i := range s {
+		if v, ok := m.At(s, i); ok {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// CalibrationValue joins the first and last numbers found in s, like
+// GetCalibrationValue2 does for English, and can be used with
+// InputToIntList.
+func (m *Matcher) CalibrationValue(s string) (int64, error) {
+	f, ok := m.First(s)
+	if !ok {
+		return -1, ErrNoDigits
+	}
+	l, _ := m.Last(s)
+	return strconv.ParseInt(strconv.Itoa(f)+strconv.Itoa(l), 10, 64)
+}
@@ -0,0 +1,102 @@
+package day1
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/pedrokiefer/adventofcode-2023/advent"
+)
+
+// Diagnostic reports a line on which a calibration found no digits.
+// Calibration is its index in the list given to SumCalibrations and Err
+// the ParseError for the line.
+type Diagnostic struct {
+	Calibration int
+	Err         error
+}
+
+// streamBatch is how many lines are handed to the calibrations at once;
+// with a few batches in flight it bounds the memory used by
+// SumCalibrations whatever the size of the input.
+const streamBatch = 1024
+
+type batch struct {
+	first int
+	lines []string
+}
+
+// SumCalibrations reads input once and sums every calibration over its
+// lines, each one running in its own goroutine, without keeping the lines
+// around. Blank lines are skipped; lines a calibration cannot read are left
+// out of its sum and reported to diagnose, when not nil, one call at a time.
+func SumCalibrations(input io.Reader, diagnose func(Diagnostic), calibrations ...func(string) (int64, error)) ([]int64, error) {
+	sums := make([]int64, len(calibrations))
+	chans := make([]chan batch, len(calibrations))
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for i, calibration := range calibrations {
+		chans[i] = make(chan batch, 4)
+		wg.Add(1)
+		go func(i int, calibration func(string) (int64, error), ch chan batch) {
+			defer wg.Done()
+			for b := range ch {
+				for j, l := range b.lines {
+					if strings.TrimSpace(l) == "" {
+						continue
+					}
+					v, err := calibration(l)
+					if err != nil {
+						if diagnose != nil {
+							mu.Lock()
+							diagnose(Diagnostic{Calibration: i, Err: advent.AtLine(err, b.first+j, l)})
+							mu.Unlock()
+						}
+						continue
+					}
+					sums[i] += v
+				}
+			}
+		}(i, calibration, chans[i])
+	}
+
+	s := bufio.NewScanner(input)
+	s.Buffer(nil, 1024*1024)
+	line := 0
+	b := batch{first: 1}
+	send := func() {
+		for _, ch := range chans {
+			ch <- b
+		}
+		b = batch{first: line + 1}
+	}
+	for s.Scan() {
+		line++
+		b.lines = append(b.lines, s.Text())
+		if len(b.lines) == streamBatch {
+			send()
+		}
+	}
+	if len(b.lines) > 0 {
+		send()
+	}
+	for _, ch := range chans {
+		close(ch)
+	}
+	wg.Wait()
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return sums, nil
+}
+
+// Calibrate sums the calibration values of both parts in a single pass.
+func Calibrate(input io.Reader, diagnose func(Diagnostic)) (int64, int64, error) {
+	sums, err := SumCalibrations(input, diagnose, GetCalibrationValue, GetCalibrationValue2)
+	if err != nil {
+		return 0, 0, err
+	}
+	return sums[0], sums[1], nil
+}
@@ -1,12 +1,11 @@
-package main
+package day14
 
 import (
-	"bufio"
+	"bytes"
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"io"
-	"log"
-	"os"
 	"strings"
 
 	"github.com/pedrokiefer/adventofcode-2023/advent"
@@ -48,10 +47,8 @@ func (c Column) nextRock(i int) int {
 }
 
 type RocksMap struct {
+	*advent.Grid[string]
 	Columns []Column
-	Map     map[advent.Point]string
-	Width   int
-	Height  int
 }
 
 func (r *RocksMap) RollNorth() {
@@ -80,27 +77,27 @@ func (r *RocksMap) Cycle() {
 func (r *RocksMap) Roll(d Direction) {
 	switch d {
 	case North:
-		for i := 0; i < r.Width; i++ {
-			r.rollLine(r.Width, func(j int) advent.Point {
-				return advent.Point{X: i, Y: j}
+		for x := 0; x < r.Width; x++ {
+			r.rollLine(r.Height, func(j int) advent.Point {
+				return advent.Point{X: x, Y: j}
 			})
 		}
 	case West:
-		for i := 0; i < r.Height; i++ {
-			r.rollLine(r.Height, func(j int) advent.Point {
-				return advent.Point{X: j, Y: i}
+		for y := 0; y < r.Height; y++ {
+			r.rollLine(r.Width, func(j int) advent.Point {
+				return advent.Point{X: j, Y: y}
 			})
 		}
 	case South:
-		for i := r.Width; i >= 0; i-- {
-			r.rollLine(r.Width, func(j int) advent.Point {
-				return advent.Point{X: r.Height - i - 1, Y: r.Width - j - 1}
+		for x := 0; x < r.Width; x++ {
+			r.rollLine(r.Height, func(j int) advent.Point {
+				return advent.Point{X: x, Y: r.Height - j - 1}
 			})
 		}
 	case East:
-		for i := r.Height; i >= 0; i-- {
-			r.rollLine(r.Height, func(j int) advent.Point {
-				return advent.Point{X: r.Height - j - 1, Y: r.Width - i - 1}
+		for y := 0; y < r.Height; y++ {
+			r.rollLine(r.Width, func(j int) advent.Point {
+				return advent.Point{X: r.Width - j - 1, Y: y}
 			})
 		}
 	}
@@ -109,16 +106,17 @@ func (r *RocksMap) Roll(d Direction) {
 func (r *RocksMap) rollLine(size int, mkPoint func(int) advent.Point) {
 	for i := 0; i < size; i++ {
 		p := mkPoint(i)
-		if r.Map[p] == CubeRock || r.Map[p] == RoundRock {
+		if r.Get(p) == CubeRock || r.Get(p) == RoundRock {
 			continue
 		}
-		if r.Map[p] == EmpySpace {
+		if r.Get(p) == EmpySpace {
 			nextRock := r.nextRock(i, size, mkPoint)
 			if nextRock == -1 {
 				continue
 			}
 			np := mkPoint(nextRock)
-			r.Map[p], r.Map[np] = r.Map[np], r.Map[p]
+			r.Set(p, RoundRock)
+			r.Set(np, EmpySpace)
 		}
 	}
 }
@@ -126,10 +124,10 @@ func (r *RocksMap) rollLine(size int, mkPoint func(int) advent.Point) {
 func (r RocksMap) nextRock(i, size int, mkPoint func(int) advent.Point) int {
 	for j := i + 1; j < size; j++ {
 		p := mkPoint(j)
-		if r.Map[p] == CubeRock {
+		if r.Get(p) == CubeRock {
 			return -1
 		}
-		if r.Map[p] == RoundRock {
+		if r.Get(p) == RoundRock {
 			return j
 		}
 	}
@@ -142,7 +140,7 @@ func (r *RocksMap) Hash() string {
 		for j := 0; j < r.Width; j++ {
 			p := advent.Point{X: j, Y: i}
 			h.Write([]byte(p.String()))
-			h.Write([]byte(r.Map[p]))
+			h.Write([]byte(r.Get(p)))
 		}
 	}
 	return fmt.Sprintf("%x", h.Sum(nil))
@@ -163,7 +161,7 @@ func (r *RocksMap) RunLongCycles() int {
 		r.Cycle()
 		h = r.Hash()
 		l := r.CalculateLoad()
-		fmt.Printf("Cycle: %d Load: %d Hash: %s\n", i, l, h)
+		advent.Debugf("Cycle: %d Load: %d Hash: %s", i, l, h)
 		if _, ok := seen[h]; !ok {
 			c := cycle{
 				Hash:       h,
@@ -179,10 +177,10 @@ func (r *RocksMap) RunLongCycles() int {
 	// We found a cycle
 	cycleStart := seen[h]
 	cycleLength = i - cycleStart.Interation
-	fmt.Printf("Cycle: %d Lenght: %d\n", i, cycleLength)
-	fmt.Printf("Skip: %d \n", (1000000000 - i))
+	advent.Debugf("Cycle: %d Lenght: %d", i, cycleLength)
+	advent.Debugf("Skip: %d", (1000000000 - i))
 	m := (1000000000 - i) % cycleLength
-	fmt.Printf("m: %d\n", m)
+	advent.Debugf("m: %d", m)
 	return seenList[cycleStart.Interation+m-1].Load
 }
 
@@ -191,7 +189,7 @@ func (r *RocksMap) CalculateLoad() int {
 	for i := 0; i < r.Height; i++ {
 		for j := 0; j < r.Width; j++ {
 			p := advent.Point{X: j, Y: i}
-			if r.Map[p] != RoundRock {
+			if r.Get(p) != RoundRock {
 				continue
 			}
 			load += r.Height - i
@@ -202,65 +200,68 @@ func (r *RocksMap) CalculateLoad() int {
 
 func (r RocksMap) Print() {
 	fmt.Printf("%s\n", strings.Repeat("-", r.Width))
-	for i := 0; i < r.Height; i++ {
-		for j := 0; j < r.Width; j++ {
-			fmt.Print(r.Map[advent.Point{X: j, Y: i}])
-		}
-		fmt.Println()
-	}
+	fmt.Print(r.Render(func(s string) string { return s }))
 	fmt.Printf("%s\n", strings.Repeat("-", r.Width))
 }
 
-func InputToRocksMap(input io.ReadCloser) RocksMap {
-	s := bufio.NewScanner(input)
+// InputToRocksMap reads the platform. In Lenient mode the rows
+// that cannot be read are skipped.
+func InputToRocksMap(input io.ReadCloser, mode advent.ParseMode) (RocksMap, error) {
 	defer input.Close()
-	cols := []Column{}
-	m := map[advent.Point]string{}
-	i := 0
-	for s.Scan() {
-		l := s.Text()
-		if i == 0 {
-			cols = make([]Column, len(l))
-		}
-		for j, c := range l {
-			m[advent.Point{X: j, Y: i}] = string(c)
-			if c == '#' {
-				cols[j] = append(cols[j], CubeRock)
-			} else if c == '.' {
-				cols[j] = append(cols[j], EmpySpace)
-			} else {
-				cols[j] = append(cols[j], RoundRock)
-			}
+	g, err := advent.ParseGrid(input, mode, func(c rune) (string, error) {
+		switch v := string(c); v {
+		case RoundRock, CubeRock, EmpySpace:
+			return v, nil
 		}
-		i++
+		return "", errors.New("invalid rock")
+	})
+	if err != nil {
+		return RocksMap{}, err
+	}
+	cols := make([]Column, g.Width)
+	for x := range cols {
+		cols[x] = g.Column(x)
 	}
 	return RocksMap{
+		Grid:    g,
 		Columns: cols,
-		Map:     m,
-		Width:   len(cols[0]),
-		Height:  len(cols),
-	}
+	}, nil
+}
+
+type Solver struct {
+	input []byte
 }
 
-func main() {
-	filename := os.Args[1]
-	f, err := os.Open(filename)
+func init() {
+	advent.Register(14, func() advent.Solver { return &Solver{} })
+}
+
+func (s *Solver) Parse(input io.ReadCloser) error {
+	defer input.Close()
+	b, err := io.ReadAll(input)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
+	if _, err := InputToRocksMap(io.NopCloser(bytes.NewReader(b)), advent.Strict); err != nil {
+		return err
+	}
+	s.input = b
+	return nil
+}
 
-	rm := InputToRocksMap(f)
-
+func (s *Solver) Part1() (int, error) {
+	rm, err := InputToRocksMap(io.NopCloser(bytes.NewReader(s.input)), advent.Strict)
+	if err != nil {
+		return 0, err
+	}
 	rm.Roll(North)
-	fmt.Printf("Load: %d\n", rm.CalculateLoad())
+	return rm.CalculateLoad(), nil
+}
 
-	f, err = os.Open(filename)
+func (s *Solver) Part2() (int, error) {
+	rm, err := InputToRocksMap(io.NopCloser(bytes.NewReader(s.input)), advent.Strict)
 	if err != nil {
-		log.Fatal(err)
+		return 0, err
 	}
-
-	rm2 := InputToRocksMap(f)
-
-	load := rm2.RunLongCycles()
-	fmt.Printf("Load: %d\n", load)
+	return rm.RunLongCycles(), nil
 }
@@ -1,4 +1,4 @@
-package main
+package day14
 
 import (
 	"bytes"
@@ -21,7 +21,8 @@ O.#..O.#.#
 #....###..
 #OO..#....`)))
 
-	v := InputToRocksMap(input)
+	v, err := InputToRocksMap(input, advent.Strict)
+	assert.Nil(t, err)
 
 	assert.Equal(t, Column{"O", "O", ".", "O", ".", "O", ".", ".", "#", "#"}, v.Columns[0])
 	assert.Equal(t, Column{".", "O", ".", ".", ".", "#", "O", ".", ".", "O"}, v.Columns[2])
@@ -58,30 +59,31 @@ O.#..O.#.#
 #....###..
 #OO..#....`)))
 
-	v := InputToRocksMap(input)
+	v, err := InputToRocksMap(input, advent.Strict)
+	assert.Nil(t, err)
 
-	assert.Equal(t, "O", v.Map[advent.Point{X: 0, Y: 0}])
-	assert.Equal(t, "#", v.Map[advent.Point{X: 5, Y: 0}])
-	assert.Equal(t, ".", v.Map[advent.Point{X: 0, Y: 2}])
+	assert.Equal(t, "O", v.Get(advent.Point{X: 0, Y: 0}))
+	assert.Equal(t, "#", v.Get(advent.Point{X: 5, Y: 0}))
+	assert.Equal(t, ".", v.Get(advent.Point{X: 0, Y: 2}))
 
 	v.Roll(North)
-	assert.Equal(t, "O", v.Map[advent.Point{X: 0, Y: 2}])
+	assert.Equal(t, "O", v.Get(advent.Point{X: 0, Y: 2}))
 
 	v.Print()
 
-	assert.Equal(t, "O", v.Map[advent.Point{X: 4, Y: 2}])
+	assert.Equal(t, "O", v.Get(advent.Point{X: 4, Y: 2}))
 	v.Roll(West)
-	assert.Equal(t, ".", v.Map[advent.Point{X: 4, Y: 2}])
+	assert.Equal(t, ".", v.Get(advent.Point{X: 4, Y: 2}))
 	v.Print()
 
-	assert.Equal(t, ".", v.Map[advent.Point{X: 2, Y: 4}])
+	assert.Equal(t, ".", v.Get(advent.Point{X: 2, Y: 4}))
 	v.Roll(South)
-	assert.Equal(t, "O", v.Map[advent.Point{X: 2, Y: 4}])
+	assert.Equal(t, "O", v.Get(advent.Point{X: 2, Y: 4}))
 	v.Print()
 
-	assert.Equal(t, ".", v.Map[advent.Point{X: 3, Y: 9}])
+	assert.Equal(t, ".", v.Get(advent.Point{X: 3, Y: 9}))
 	v.Roll(East)
-	assert.Equal(t, "O", v.Map[advent.Point{X: 3, Y: 9}])
+	assert.Equal(t, "O", v.Get(advent.Point{X: 3, Y: 9}))
 	v.Print()
 }
 
@@ -97,10 +99,11 @@ O.#..O.#.#
 #....###..
 #OO..#....`)))
 
-	v := InputToRocksMap(input)
+	v, err := InputToRocksMap(input, advent.Strict)
+	assert.Nil(t, err)
 	v.Cycle()
-	assert.Equal(t, ".", v.Map[advent.Point{X: 0, Y: 0}])
-	assert.Equal(t, "O", v.Map[advent.Point{X: 3, Y: 9}])
+	assert.Equal(t, ".", v.Get(advent.Point{X: 0, Y: 0}))
+	assert.Equal(t, "O", v.Get(advent.Point{X: 3, Y: 9}))
 }
 
 func TestRunLongCycles(t *testing.T) {
@@ -115,9 +118,23 @@ O.#..O.#.#
 #....###..
 #OO..#....`)))
 
-	v := InputToRocksMap(input)
+	v, err := InputToRocksMap(input, advent.Strict)
+	assert.Nil(t, err)
 	load := v.RunLongCycles()
-	assert.Equal(t, ".", v.Map[advent.Point{X: 0, Y: 0}])
-	assert.Equal(t, "O", v.Map[advent.Point{X: 3, Y: 9}])
+	assert.Equal(t, ".", v.Get(advent.Point{X: 0, Y: 0}))
+	assert.Equal(t, "O", v.Get(advent.Point{X: 3, Y: 9}))
 	assert.Equal(t, 64, load)
 }
+
+func TestInputToRocksMapErrors(t *testing.T) {
+	_, err := InputToRocksMap(io.NopCloser(bytes.NewReader([]byte("O.#\n.\n"))), advent.Strict)
+	assert.EqualError(t, err, "line 2: expected 3 cells, got 1")
+
+	_, err = InputToRocksMap(io.NopCloser(bytes.NewReader([]byte("O.#\n.x.\n"))), advent.Strict)
+	assert.EqualError(t, err, `line 2, column 2: parsing "x": invalid rock`)
+
+	v, err := InputToRocksMap(io.NopCloser(bytes.NewReader([]byte("O.#\n.x.\n#O.\n"))), advent.Lenient)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, v.Height)
+	assert.Equal(t, []Column{{"O", "#"}, {".", "O"}, {"#", "."}}, v.Columns)
+}
@@ -0,0 +1,190 @@
+package day15
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pedrokiefer/adventofcode-2023/advent"
+)
+
+type Lens struct {
+	Label       string
+	Box         int
+	FocalLength int
+}
+
+type OperationType string
+
+var (
+	AddLens    = OperationType("=")
+	RemoveLens = OperationType("-")
+)
+
+type Operation struct {
+	Label       string
+	Box         int
+	Type        OperationType
+	FocalLength int
+}
+
+type Box struct {
+	Lenses []*Lens
+}
+
+// InputToInitializationSequence reads the comma separated steps, checking
+// that every one is an operation. In Lenient mode the steps that are not
+// are skipped.
+func InputToInitializationSequence(input io.ReadCloser, mode advent.ParseMode) ([]string, error) {
+	result := []string{}
+	defer input.Close()
+	err := advent.ParseLines(input, mode, func(l string) error {
+		column := len(l) - len(strings.TrimLeft(l, " \t")) + 1
+		for _, step := range strings.Split(strings.TrimSpace(l), ",") {
+			if _, err := InputToOperation(step); err != nil {
+				if mode == advent.Strict {
+					return advent.Offset(err, column)
+				}
+			} else {
+				result = append(result, step)
+			}
+			column += len(step) + 1
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func HolidayASCIIStringHelperAlgorithm(i string) int {
+	hash := 0
+	for _, c := range i {
+		hash += int(c)
+		hash *= 17
+		hash %= 256
+	}
+	return hash
+}
+
+func InitializationSequenceHASH(is []string) int {
+	sum := 0
+	for _, i := range is {
+		sum += HolidayASCIIStringHelperAlgorithm(i)
+	}
+	return sum
+}
+
+// InputToOperation reads a "label=focal length" or "label-" step.
+func InputToOperation(i string) (Operation, error) {
+	if label, focalLength, ok := strings.Cut(i, "="); ok {
+		f, err := strconv.Atoi(focalLength)
+		if err != nil {
+			return Operation{}, &advent.ParseError{
+				Column: len(label) + 2,
+				Text:   focalLength,
+				Err:    errors.New("invalid focal length"),
+			}
+		}
+		return Operation{
+			Label:       label,
+			Box:         HolidayASCIIStringHelperAlgorithm(label),
+			Type:        AddLens,
+			FocalLength: f,
+		}, nil
+	}
+	if label, ok := strings.CutSuffix(i, "-"); ok {
+		return Operation{
+			Label: label,
+			Box:   HolidayASCIIStringHelperAlgorithm(label),
+			Type:  RemoveLens,
+		}, nil
+	}
+	return Operation{}, &advent.ParseError{
+		Column: 1,
+		Text:   i,
+		Err:    errors.New("expected an = or - operation"),
+	}
+}
+
+func (b *Box) GetLens(label string) (*Lens, bool) {
+	for _, l := range b.Lenses {
+		if l.Label == label {
+			return l, true
+		}
+	}
+	return nil, false
+}
+
+func ManualArrangementProcedure(is []string) ([]Box, error) {
+	boxes := make([]Box, 256)
+	for _, i := range is {
+		op, err := InputToOperation(i)
+		if err != nil {
+			return nil, err
+		}
+		if op.Type == AddLens {
+			if l, ok := boxes[op.Box].GetLens(op.Label); ok {
+				l.FocalLength = op.FocalLength
+			} else {
+				boxes[op.Box].Lenses = append(boxes[op.Box].Lenses, &Lens{
+					Label:       op.Label,
+					Box:         op.Box,
+					FocalLength: op.FocalLength,
+				})
+			}
+		} else if op.Type == RemoveLens {
+			for j, l := range boxes[op.Box].Lenses {
+				if l.Label == op.Label {
+					boxes[op.Box].Lenses = append(boxes[op.Box].Lenses[:j], boxes[op.Box].Lenses[j+1:]...)
+					break
+				}
+			}
+		}
+	}
+	return boxes, nil
+}
+
+func (b *Box) FocusingPower() int {
+	sum := 0
+	for c, l := range b.Lenses {
+		p := (l.Box + 1) * (c + 1) * l.FocalLength
+		sum += p
+	}
+	return sum
+}
+
+type Solver struct {
+	is []string
+}
+
+func init() {
+	advent.Register(15, func() advent.Solver { return &Solver{} })
+}
+
+func (s *Solver) Parse(input io.ReadCloser) error {
+	is, err := InputToInitializationSequence(input, advent.Strict)
+	if err != nil {
+		return err
+	}
+	s.is = is
+	return nil
+}
+
+func (s *Solver) Part1() (int, error) {
+	return InitializationSequenceHASH(s.is), nil
+}
+
+func (s *Solver) Part2() (int, error) {
+	boxes, err := ManualArrangementProcedure(s.is)
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for _, b := range boxes {
+		total += b.FocusingPower()
+	}
+	return total, nil
+}
@@ -1,16 +1,18 @@
-package main
+package day15
 
 import (
 	"bytes"
 	"io"
 	"testing"
 
+	"github.com/pedrokiefer/adventofcode-2023/advent"
 	"github.com/stretchr/testify/assert"
 )
 
 func Test(t *testing.T) {
 	input := io.NopCloser(bytes.NewReader([]byte(`rn=1,cm-,qp=3,cm=2,qp-,pc=4,ot=9,ab=5,pc-,pc=6,ot=7`)))
-	is := InputToInitializationSequence(input)
+	is, err := InputToInitializationSequence(input, advent.Strict)
+	assert.Nil(t, err)
 
 	assert.Equal(t, []string{"rn=1", "cm-", "qp=3", "cm=2", "qp-", "pc=4", "ot=9", "ab=5", "pc-", "pc=6", "ot=7"}, is)
 
@@ -24,7 +26,8 @@ func TestHolidayASCIIStringHelperAlgorithm(t *testing.T) {
 }
 
 func TestManualArrangementProcedure(t *testing.T) {
-	op := InputToOperation("rn=1")
+	op, err := InputToOperation("rn=1")
+	assert.Nil(t, err)
 	assert.Equal(t, Operation{
 		Label:       "rn",
 		Box:         0,
@@ -32,7 +35,8 @@ func TestManualArrangementProcedure(t *testing.T) {
 		FocalLength: 1,
 	}, op)
 
-	op2 := InputToOperation("cm-")
+	op2, err := InputToOperation("cm-")
+	assert.Nil(t, err)
 	assert.Equal(t, Operation{
 		Label:       "cm",
 		Box:         0,
@@ -40,7 +44,8 @@ func TestManualArrangementProcedure(t *testing.T) {
 		FocalLength: 0,
 	}, op2)
 
-	b := ManualArrangementProcedure([]string{"rn=1", "cm-", "qp=3", "cm=2", "qp-", "pc=4", "ot=9", "ab=5", "pc-", "pc=6", "ot=7"})
+	b, err := ManualArrangementProcedure([]string{"rn=1", "cm-", "qp=3", "cm=2", "qp-", "pc=4", "ot=9", "ab=5", "pc-", "pc=6", "ot=7"})
+	assert.Nil(t, err)
 	assert.Equal(t, Box{
 		Lenses: []*Lens{
 			{Label: "rn", Box: 0, FocalLength: 1},
@@ -60,3 +65,17 @@ func TestManualArrangementProcedure(t *testing.T) {
 	assert.Equal(t, 0, b[2].FocusingPower())
 	assert.Equal(t, 140, b[3].FocusingPower())
 }
+
+func TestInputToInitializationSequenceErrors(t *testing.T) {
+	input := []byte("rn=1,cm-,qp=x,cm=2\nqp\n")
+
+	_, err := InputToInitializationSequence(io.NopCloser(bytes.NewReader(input)), advent.Strict)
+	assert.EqualError(t, err, `line 1, column 13: parsing "x": invalid focal length`)
+
+	is, err := InputToInitializationSequence(io.NopCloser(bytes.NewReader(input)), advent.Lenient)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"rn=1", "cm-", "cm=2"}, is)
+
+	_, err = InputToOperation("qp")
+	assert.EqualError(t, err, `column 1: parsing "qp": expected an = or - operation`)
+}
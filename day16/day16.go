@@ -0,0 +1,390 @@
+package day16
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pedrokiefer/adventofcode-2023/advent"
+)
+
+type Element string
+
+var (
+	EmptySpace         = Element(".")
+	VerticalSplitter   = Element("|")
+	HorizontalSplitter = Element("-")
+	MirrorUpward       = Element("/")
+	MirrorDownward     = Element("\\")
+)
+
+type Directions []advent.Direction
+
+func (d Directions) Contains(p advent.Direction) bool {
+	for _, v := range d {
+		if v == p {
+			return true
+		}
+	}
+	return false
+}
+
+type Cavern struct {
+	*advent.Grid[Element]
+}
+
+// ScatterMap tracks the tiles a Beam went through. Map records, in order of
+// arrival, the directions that crossed each tile and is only filled when
+// created with NewScatterMap; the zero value just counts energized tiles.
+type ScatterMap struct {
+	Map            map[advent.Point]Directions
+	StartPosition  advent.Point
+	StartDirection advent.Direction
+	Energized      int
+
+	visited bitset
+}
+
+// bitset holds one bit per tile and direction.
+type bitset []uint64
+
+func (b bitset) set(i int) bool {
+	w, m := i/64, uint64(1)<<(i%64)
+	if b[w]&m != 0 {
+		return false
+	}
+	b[w] |= m
+	return true
+}
+
+func (b bitset) any4(i int) bool {
+	return (b[i/64]>>(i%64))&0xf != 0
+}
+
+func NewScatterMap() ScatterMap {
+	return ScatterMap{
+		Map:       map[advent.Point]Directions{},
+		Energized: 0,
+	}
+}
+
+// InputToCavern reads the contraption. In Lenient mode the rows
+// that cannot be read are skipped.
+func InputToCavern(input io.ReadCloser, mode advent.ParseMode) (Cavern, error) {
+	defer input.Close()
+	g, err := advent.ParseGrid(input, mode, func(c rune) (Element, error) {
+		switch e := Element(string(c)); e {
+		case EmptySpace, VerticalSplitter, HorizontalSplitter, MirrorUpward, MirrorDownward:
+			return e, nil
+		}
+		return "", errors.New("invalid element")
+	})
+	if err != nil {
+		return Cavern{}, err
+	}
+	return Cavern{Grid: g}, nil
+}
+
+type Beam struct {
+	Position  advent.Point
+	Direction advent.Direction
+}
+
+// reset forgets the tiles crossed so far, sizing the bitset for c.
+func (sm *ScatterMap) reset(c Cavern) {
+	if n := (c.Width*c.Height*4 + 63) / 64; len(sm.visited) != n {
+		sm.visited = make(bitset, n)
+	} else {
+		clear(sm.visited)
+	}
+	if sm.Map != nil {
+		clear(sm.Map)
+	}
+	sm.Energized = 0
+}
+
+// visit marks p as crossed by d, returning false when it already was.
+func (sm *ScatterMap) visit(c Cavern, p advent.Point, d advent.Direction) bool {
+	i := (p.Y*c.Width + p.X) * 4
+	first := !sm.visited.any4(i)
+	if !sm.visited.set(i + int(d)) {
+		return false
+	}
+	if first {
+		sm.Energized++
+	}
+	if sm.Map != nil {
+		sm.Map[p] = append(sm.Map[p], d)
+	}
+	return true
+}
+
+// Deflect returns the directions a Beam moving towards d leaves the element
+// in, the second one only being valid when the Beam was split.
+func (e Element) Deflect(d advent.Direction) (advent.Direction, advent.Direction, bool) {
+	switch e {
+	case EmptySpace:
+		return d, d, false
+	case VerticalSplitter:
+		if d == advent.Up || d == advent.Down {
+			return d, d, false
+		}
+		return advent.Up, advent.Down, true
+	case HorizontalSplitter:
+		if d == advent.Left || d == advent.Right {
+			return d, d, false
+		}
+		return advent.Left, advent.Right, true
+	case MirrorUpward:
+		switch d {
+		case advent.Up, advent.Down:
+			return d.TurnRight(), d, false
+		default:
+			return d.TurnLeft(), d, false
+		}
+	case MirrorDownward:
+		switch d {
+		case advent.Up, advent.Down:
+			return d.TurnLeft(), d, false
+		default:
+			return d.TurnRight(), d, false
+		}
+	}
+	return d, d, false
+}
+
+// ScatterLight follows a Beam entering p towards d, replacing what an
+// earlier call recorded. Beams are walked one at a time, the second half of
+// each split waiting on a stack, so memory does not depend on how long the
+// corridors are.
+func (sm *ScatterMap) ScatterLight(c Cavern, d advent.Direction, p advent.Point) {
+	sm.reset(c)
+	stack := []Beam{{Position: p, Direction: d}}
+	for len(stack) > 0 {
+		b := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		p, d := b.Position, b.Direction
+		for c.InBounds(p) && sm.visit(c, p, d) {
+			e := c.Get(p)
+			d1, d2, split := e.Deflect(d)
+			if split {
+				stack = append(stack, Beam{Position: p.Move(d2), Direction: d2})
+			}
+			d = d1
+			p = p.Move(d)
+		}
+	}
+}
+
+// EdgeStarts lists every Beam entering the cavern from its border: down and
+// up for each column, then right and left for each row.
+func EdgeStarts(c Cavern) []Beam {
+	starts := []Beam{}
+	for x := 0; x < c.Width; x++ {
+		starts = append(starts,
+			Beam{Position: advent.Point{X: x, Y: 0}, Direction: advent.Down},
+			Beam{Position: advent.Point{X: x, Y: c.Height - 1}, Direction: advent.Up},
+		)
+	}
+	for y := 0; y < c.Height; y++ {
+		starts = append(starts,
+			Beam{Position: advent.Point{X: 0, Y: y}, Direction: advent.Right},
+			Beam{Position: advent.Point{X: c.Width - 1, Y: y}, Direction: advent.Left},
+		)
+	}
+	return starts
+}
+
+func energize(c Cavern, b Beam) int {
+	sm := ScatterMap{}
+	sm.ScatterLight(c, b.Direction, b.Position)
+	return sm.Energized
+}
+
+// FindMaximumScatterMap finds the border start energizing the most tiles,
+// spreading the starts over one worker per CPU.
+func FindMaximumScatterMap(c Cavern) ScatterMap {
+	return FindMaximumScatterMapParallel(c, runtime.NumCPU())
+}
+
+func FindMaximumScatterMapSerial(c Cavern) ScatterMap {
+	starts := EdgeStarts(c)
+	energized := make([]int, len(starts))
+	for i, b := range starts {
+		energized[i] = energize(c, b)
+	}
+	return maximumScatterMap(c, starts, energized)
+}
+
+func FindMaximumScatterMapParallel(c Cavern, workers int) ScatterMap {
+	starts := EdgeStarts(c)
+	energized := make([]int, len(starts))
+
+	jobs := make(chan int)
+	wg := sync.WaitGroup{}
+	for w := 0; w < max(workers, 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				energized[i] = energize(c, starts[i])
+			}
+		}()
+	}
+	for i := range starts {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return maximumScatterMap(c, starts, energized)
+}
+
+// maximumScatterMap picks the start with the most energized tiles, the first
+// one in EdgeStarts order winning ties, and scatters it again recording the
+// directions that crossed every tile.
+func maximumScatterMap(c Cavern, starts []Beam, energized []int) ScatterMap {
+	best := -1
+	for i, e := range energized {
+		if best == -1 || e > energized[best] {
+			advent.Debugf("New maximum: %v position: %v direction: %v", e, starts[i].Position, starts[i].Direction)
+			best = i
+		}
+	}
+	if best == -1 {
+		return NewScatterMap()
+	}
+
+	sm := NewScatterMap()
+	sm.StartPosition = starts[best].Position
+	sm.StartDirection = starts[best].Direction
+	sm.ScatterLight(c, sm.StartDirection, sm.StartPosition)
+	return sm
+}
+
+var arrows = map[advent.Direction]string{
+	advent.Up:    "^",
+	advent.Right: ">",
+	advent.Down:  "v",
+	advent.Left:  "<",
+}
+
+// Render draws the beams over the cavern like the puzzle statement does:
+// mirrors and splitters are kept, empty tiles crossed by a single beam show
+// its direction and the ones crossed by several show how many.
+func (sm ScatterMap) Render(c Cavern) string {
+	sb := strings.Builder{}
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			p := advent.Point{X: x, Y: y}
+			e := c.Get(p)
+			d := sm.Map[p]
+			switch {
+			case e != EmptySpace || len(d) == 0:
+				sb.WriteString(string(e))
+			case len(d) == 1:
+				sb.WriteString(arrows[d[0]])
+			default:
+				sb.WriteString(strconv.Itoa(len(d)))
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// RenderEnergized draws energized tiles as # and every other tile as a dot.
+func (sm ScatterMap) RenderEnergized(c Cavern) string {
+	sb := strings.Builder{}
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			if len(sm.Map[advent.Point{X: x, Y: y}]) > 0 {
+				sb.WriteString("#")
+			} else {
+				sb.WriteString(".")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+var (
+	darkColor    = color.RGBA{R: 0x0f, G: 0x0f, B: 0x23, A: 0xff}
+	elementColor = color.RGBA{R: 0xcc, G: 0xcc, B: 0xcc, A: 0xff}
+)
+
+// beamColor gets brighter the more beams cross the tile.
+func beamColor(count int) color.RGBA {
+	if count == 0 {
+		return darkColor
+	}
+	v := uint8(0x60 + 0x28*min(count, 4))
+	return color.RGBA{R: v, G: v, B: 0x20, A: 0xff}
+}
+
+// Image draws every tile as a scale by scale square colored by how many
+// beams crossed it, with mirrors and splitters drawn as lines on top.
+func (sm ScatterMap) Image(c Cavern, scale int) *image.RGBA {
+	scale = max(scale, 1)
+	img := image.NewRGBA(image.Rect(0, 0, c.Width*scale, c.Height*scale))
+	c.Each(func(p advent.Point, e Element) {
+		x0, y0 := p.X*scale, p.Y*scale
+		bc := beamColor(len(sm.Map[p]))
+		for i := 0; i < scale; i++ {
+			for j := 0; j < scale; j++ {
+				img.SetRGBA(x0+i, y0+j, bc)
+			}
+		}
+		for i := 0; i < scale; i++ {
+			switch e {
+			case VerticalSplitter:
+				img.SetRGBA(x0+scale/2, y0+i, elementColor)
+			case HorizontalSplitter:
+				img.SetRGBA(x0+i, y0+scale/2, elementColor)
+			case MirrorUpward:
+				img.SetRGBA(x0+i, y0+scale-1-i, elementColor)
+			case MirrorDownward:
+				img.SetRGBA(x0+i, y0+i, elementColor)
+			}
+		}
+	})
+	return img
+}
+
+func (sm ScatterMap) WritePNG(w io.Writer, c Cavern, scale int) error {
+	return png.Encode(w, sm.Image(c, scale))
+}
+
+type Solver struct {
+	cavern Cavern
+}
+
+func init() {
+	advent.Register(16, func() advent.Solver { return &Solver{} })
+}
+
+func (s *Solver) Parse(input io.ReadCloser) error {
+	c, err := InputToCavern(input, advent.Strict)
+	if err != nil {
+		return err
+	}
+	s.cavern = c
+	return nil
+}
+
+func (s *Solver) Part1() (int, error) {
+	sm := NewScatterMap()
+	sm.ScatterLight(s.cavern, advent.Right, advent.Point{X: 0, Y: 0})
+	return sm.Energized, nil
+}
+
+func (s *Solver) Part2() (int, error) {
+	return FindMaximumScatterMap(s.cavern).Energized, nil
+}
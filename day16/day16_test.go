@@ -1,10 +1,16 @@
-package main
+package day16
 
 import (
 	"bytes"
+	"image"
+	"image/color"
+	"image/png"
 	"io"
+	"os"
+	"runtime"
 	"testing"
 
+	"github.com/pedrokiefer/adventofcode-2023/advent"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -19,11 +25,12 @@ func TestParseCavern(t *testing.T) {
 .-.-/..|..
 .|....-|.\
 ..//.|....`)))
-	c := InputToCavern(input)
+	c, err := InputToCavern(input, advent.Strict)
+	assert.Nil(t, err)
 
 	assert.Equal(t, 10, c.Width)
 	assert.Equal(t, 10, c.Height)
-	assert.Equal(t, VerticalSplitter, c.Elements[Position{X: 1, Y: 0}])
+	assert.Equal(t, VerticalSplitter, c.Get(advent.Point{X: 1, Y: 0}))
 }
 
 func TestScatterLight(t *testing.T) {
@@ -37,24 +44,25 @@ func TestScatterLight(t *testing.T) {
 .-.-/..|..
 .|....-|.\
 ..//.|....`)))
-	c := InputToCavern(input)
+	c, err := InputToCavern(input, advent.Strict)
+	assert.Nil(t, err)
 
 	assert.Equal(t, 10, c.Width)
 	assert.Equal(t, 10, c.Height)
-	assert.Equal(t, VerticalSplitter, c.Elements[Position{X: 1, Y: 0}])
+	assert.Equal(t, VerticalSplitter, c.Get(advent.Point{X: 1, Y: 0}))
 
 	sm := NewScatterMap()
-	sm.ScatterLight(c, Right, Position{X: 0, Y: 0})
-
-	assert.Equal(t, Directions{Right}, sm.Map[Position{X: 0, Y: 0}])
-	assert.Equal(t, Directions{Right, Left}, sm.Map[Position{X: 1, Y: 0}])
-	assert.Equal(t, Directions{Down}, sm.Map[Position{X: 1, Y: 1}])
-	assert.Equal(t, Directions{Left}, sm.Map[Position{X: 0, Y: 7}])
-	assert.Equal(t, Directions{Down, Up}, sm.Map[Position{X: 1, Y: 7}])
-	assert.Equal(t, Directions{Right}, sm.Map[Position{X: 4, Y: 7}])
-	assert.Equal(t, Directions{Up}, sm.Map[Position{X: 4, Y: 6}])
-	assert.Equal(t, Directions{Right, Down}, sm.Map[Position{X: 5, Y: 6}])
-	assert.Equal(t, Directions{Right, Left}, sm.Map[Position{X: 6, Y: 6}])
+	sm.ScatterLight(c, advent.Right, advent.Point{X: 0, Y: 0})
+
+	assert.Equal(t, Directions{advent.Right}, sm.Map[advent.Point{X: 0, Y: 0}])
+	assert.Equal(t, Directions{advent.Right, advent.Left}, sm.Map[advent.Point{X: 1, Y: 0}])
+	assert.Equal(t, Directions{advent.Down}, sm.Map[advent.Point{X: 1, Y: 1}])
+	assert.Equal(t, Directions{advent.Left}, sm.Map[advent.Point{X: 0, Y: 7}])
+	assert.Equal(t, Directions{advent.Down, advent.Up}, sm.Map[advent.Point{X: 1, Y: 7}])
+	assert.Equal(t, Directions{advent.Right}, sm.Map[advent.Point{X: 4, Y: 7}])
+	assert.Equal(t, Directions{advent.Up}, sm.Map[advent.Point{X: 4, Y: 6}])
+	assert.Equal(t, Directions{advent.Right, advent.Down}, sm.Map[advent.Point{X: 5, Y: 6}])
+	assert.Equal(t, Directions{advent.Right, advent.Left}, sm.Map[advent.Point{X: 6, Y: 6}])
 
 	assert.Equal(t, 46, sm.Energized)
 }
@@ -70,16 +78,218 @@ func TestScatterLightMaximum(t *testing.T) {
 .-.-/..|..
 .|....-|.\
 ..//.|....`)))
-	c := InputToCavern(input)
+	c, err := InputToCavern(input, advent.Strict)
+	assert.Nil(t, err)
 
 	assert.Equal(t, 10, c.Width)
 	assert.Equal(t, 10, c.Height)
-	assert.Equal(t, VerticalSplitter, c.Elements[Position{X: 1, Y: 0}])
+	assert.Equal(t, VerticalSplitter, c.Get(advent.Point{X: 1, Y: 0}))
 
 	sm := FindMaximumScatterMap(c)
 
-	assert.Equal(t, Position{X: 3, Y: 0}, sm.StartPosition)
-	assert.Equal(t, Down, sm.StartDirection)
+	assert.Equal(t, advent.Point{X: 3, Y: 0}, sm.StartPosition)
+	assert.Equal(t, advent.Down, sm.StartDirection)
 	assert.Equal(t, 51, sm.Energized)
 
 }
+
+// serpentine builds a cavern whose single beam snakes through every tile,
+// going right on even rows and left on odd ones.
+func serpentine(width, height int) Cavern {
+	g := advent.NewGrid[Element](width, height)
+	for i := range g.Cells {
+		g.Cells[i] = EmptySpace
+	}
+	for y := 0; y < height; y++ {
+		if y%2 == 0 {
+			g.Set(advent.Point{X: width - 1, Y: y}, MirrorDownward)
+			if y > 0 {
+				g.Set(advent.Point{X: 0, Y: y}, MirrorDownward)
+			}
+		} else {
+			g.Set(advent.Point{X: width - 1, Y: y}, MirrorUpward)
+			g.Set(advent.Point{X: 0, Y: y}, MirrorUpward)
+		}
+	}
+	return Cavern{Grid: g}
+}
+
+func TestScatterLightLargeCavern(t *testing.T) {
+	c := serpentine(2000, 2000)
+
+	sm := ScatterMap{}
+	sm.ScatterLight(c, advent.Right, advent.Point{X: 0, Y: 0})
+
+	assert.Equal(t, 2000*2000, sm.Energized)
+	assert.Nil(t, sm.Map)
+}
+
+func TestScatterLightReuse(t *testing.T) {
+	sm := ScatterMap{}
+	sm.ScatterLight(serpentine(4, 2), advent.Right, advent.Point{X: 0, Y: 0})
+	assert.Equal(t, 8, sm.Energized)
+
+	sm.ScatterLight(serpentine(4, 8), advent.Right, advent.Point{X: 0, Y: 0})
+	assert.Equal(t, 32, sm.Energized)
+
+	sm.ScatterLight(serpentine(4, 8), advent.Left, advent.Point{X: 3, Y: 7})
+	assert.Equal(t, energize(serpentine(4, 8), Beam{Position: advent.Point{X: 3, Y: 7}, Direction: advent.Left}), sm.Energized)
+
+	sm = NewScatterMap()
+	sm.ScatterLight(serpentine(4, 8), advent.Right, advent.Point{X: 0, Y: 0})
+	sm.ScatterLight(serpentine(4, 2), advent.Right, advent.Point{X: 0, Y: 0})
+	assert.Equal(t, 8, sm.Energized)
+	assert.Equal(t, 8, len(sm.Map))
+}
+
+func TestScatterMaximumKeepsDirections(t *testing.T) {
+	c := serpentine(4, 4)
+
+	sm := FindMaximumScatterMap(c)
+
+	assert.Equal(t, 16, sm.Energized)
+	assert.Equal(t, 16, len(sm.Map))
+}
+
+func TestScatterLightMaximumParallel(t *testing.T) {
+	input := io.NopCloser(bytes.NewReader([]byte(`.|...\....
+|.-.\.....
+.....|-...
+........|.
+..........
+.........\
+..../.\\..
+.-.-/..|..
+.|....-|.\
+..//.|....`)))
+	c, err := InputToCavern(input, advent.Strict)
+	assert.Nil(t, err)
+
+	serial := FindMaximumScatterMapSerial(c)
+	for _, workers := range []int{0, 1, 3, 8, 64} {
+		sm := FindMaximumScatterMapParallel(c, workers)
+		assert.Equal(t, serial, sm)
+	}
+	assert.Equal(t, advent.Point{X: 3, Y: 0}, serial.StartPosition)
+	assert.Equal(t, 51, serial.Energized)
+}
+
+func TestScatterLightMaximumTieBreak(t *testing.T) {
+	c := serpentine(4, 4)
+
+	for i := 0; i < 10; i++ {
+		sm := FindMaximumScatterMapParallel(c, 4)
+		assert.Equal(t, advent.Point{X: 0, Y: 3}, sm.StartPosition)
+		assert.Equal(t, advent.Up, sm.StartDirection)
+	}
+}
+
+func benchmarkCavern(b *testing.B) Cavern {
+	f, err := os.Open("input.txt")
+	if err != nil {
+		b.Skip("input.txt not available")
+	}
+	c, err := InputToCavern(f, advent.Strict)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return c
+}
+
+func BenchmarkFindMaximumScatterMapSerial(b *testing.B) {
+	c := benchmarkCavern(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		FindMaximumScatterMapSerial(c)
+	}
+}
+
+func BenchmarkFindMaximumScatterMapParallel(b *testing.B) {
+	c := benchmarkCavern(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		FindMaximumScatterMapParallel(c, runtime.NumCPU())
+	}
+}
+
+func TestRender(t *testing.T) {
+	input := io.NopCloser(bytes.NewReader([]byte(`.|...\....
+|.-.\.....
+.....|-...
+........|.
+..........
+.........\
+..../.\\..
+.-.-/..|..
+.|....-|.\
+..//.|....`)))
+	c, err := InputToCavern(input, advent.Strict)
+	assert.Nil(t, err)
+
+	sm := NewScatterMap()
+	sm.ScatterLight(c, advent.Right, advent.Point{X: 0, Y: 0})
+
+	assert.Equal(t, `>|<<<\....
+|v-.\^....
+.v...|->>>
+.v...v^.|.
+.v...v^...
+.v...v^..\
+.v../2\\..
+<->-/vv|..
+.|<<<2-|.\
+.v//.|.v..
+`, sm.Render(c))
+
+	assert.Equal(t, `######....
+.#...#....
+.#...#####
+.#...##...
+.#...##...
+.#...##...
+.#..####..
+########..
+.#######..
+.#...#.#..
+`, sm.RenderEnergized(c))
+}
+
+func TestWritePNG(t *testing.T) {
+	input := io.NopCloser(bytes.NewReader([]byte(`.|...\....
+|.-.\.....
+.....|-...
+........|.
+..........
+.........\
+..../.\\..
+.-.-/..|..
+.|....-|.\
+..//.|....`)))
+	c, err := InputToCavern(input, advent.Strict)
+	assert.Nil(t, err)
+
+	sm := NewScatterMap()
+	sm.ScatterLight(c, advent.Right, advent.Point{X: 0, Y: 0})
+
+	b := &bytes.Buffer{}
+	assert.Nil(t, sm.WritePNG(b, c, 5))
+
+	img, err := png.Decode(b)
+	assert.Nil(t, err)
+	assert.Equal(t, image.Rect(0, 0, 50, 50), img.Bounds())
+	assert.Equal(t, beamColor(0), color.RGBAModel.Convert(img.At(49, 49)))
+	assert.Equal(t, beamColor(1), color.RGBAModel.Convert(img.At(0, 0)))
+	assert.Equal(t, beamColor(2), color.RGBAModel.Convert(img.At(25, 30)))
+	assert.Equal(t, elementColor, color.RGBAModel.Convert(img.At(7, 2)))
+}
+
+func TestInputToCavernErrors(t *testing.T) {
+	_, err := InputToCavern(io.NopCloser(bytes.NewReader([]byte(".|.\n-\n"))), advent.Strict)
+	assert.EqualError(t, err, "line 2: expected 3 cells, got 1")
+
+	_, err = InputToCavern(io.NopCloser(bytes.NewReader([]byte(`.|.
+./x`))), advent.Strict)
+	var pe *advent.ParseError
+	assert.ErrorAs(t, err, &pe)
+	assert.Equal(t, advent.ParseError{Line: 2, Column: 3, Text: "x", Err: pe.Err}, *pe)
+}
@@ -0,0 +1,134 @@
+package day17
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/pedrokiefer/adventofcode-2023/advent"
+	"github.com/pedrokiefer/adventofcode-2023/advent/search"
+)
+
+type BlockMap struct {
+	*advent.Grid[int]
+}
+
+// InputToBlockMap reads the heat loss of every city block. In Lenient mode
+// the rows that cannot be read are skipped.
+func InputToBlockMap(input io.ReadCloser, mode advent.ParseMode) (*BlockMap, error) {
+	defer input.Close()
+	g, err := advent.ParseGrid(input, mode, func(c rune) (int, error) {
+		return strconv.Atoi(string(c))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &BlockMap{Grid: g}, nil
+}
+
+// Crucible limits how many blocks can be moved in a straight line: at least
+// MinRun before turning or stopping, and at most MaxRun.
+type Crucible struct {
+	MinRun int
+	MaxRun int
+}
+
+var (
+	NormalCrucible = Crucible{MinRun: 1, MaxRun: 3}
+	UltraCrucible  = Crucible{MinRun: 4, MaxRun: 10}
+)
+
+type State struct {
+	Position  advent.Point
+	Direction advent.Direction
+	Run       int
+}
+
+func (bm *BlockMap) next(c Crucible, s State) []State {
+	result := []State{}
+	if s.Run < c.MaxRun {
+		result = append(result, State{
+			Position:  s.Position.Move(s.Direction),
+			Direction: s.Direction,
+			Run:       s.Run + 1,
+		})
+	}
+	if s.Run >= c.MinRun {
+		for _, d := range []advent.Direction{s.Direction.TurnLeft(), s.Direction.TurnRight()} {
+			result = append(result, State{
+				Position:  s.Position.Move(d),
+				Direction: d,
+				Run:       1,
+			})
+		}
+	}
+	return result
+}
+
+// MinimalHeatLoss finds the path from the top left to the bottom right block
+// that incurs the least heat loss, using Dijkstra over (position, direction,
+// run) states. It returns false when the destination cannot be reached; a
+// single block map costs nothing, the crucible never leaving its block.
+func (bm *BlockMap) MinimalHeatLoss(c Crucible) (int, bool) {
+	start := advent.Point{X: 0, Y: 0}
+	end := advent.Point{X: bm.Width - 1, Y: bm.Height - 1}
+	if start == end {
+		return 0, true
+	}
+
+	r, ok := search.Dijkstra(
+		[]State{
+			{Position: start, Direction: advent.Right},
+			{Position: start, Direction: advent.Down},
+		},
+		func(s State) []search.Edge[State] {
+			edges := []search.Edge[State]{}
+			for _, n := range bm.next(c, s) {
+				if v, ok := bm.Lookup(n.Position); ok {
+					edges = append(edges, search.Edge[State]{To: n, Cost: v})
+				}
+			}
+			return edges
+		},
+		func(s State) bool {
+			return s.Position == end && s.Run >= c.MinRun
+		},
+	)
+	if !ok {
+		return 0, false
+	}
+	return r.Cost, true
+}
+
+type Solver struct {
+	bm *BlockMap
+}
+
+func init() {
+	advent.Register(17, func() advent.Solver { return &Solver{} })
+}
+
+func (s *Solver) Parse(input io.ReadCloser) error {
+	bm, err := InputToBlockMap(input, advent.Strict)
+	if err != nil {
+		return err
+	}
+	s.bm = bm
+	return nil
+}
+
+func (s *Solver) minimalHeatLoss(c Crucible) (int, error) {
+	v, ok := s.bm.MinimalHeatLoss(c)
+	if !ok {
+		return 0, fmt.Errorf("no path from %s to %s", advent.Point{X: 0, Y: 0}, advent.Point{X: s.bm.Width - 1, Y: s.bm.Height - 1})
+	}
+	return v, nil
+}
+
+func (s *Solver) Part1() (int, error) {
+	return s.minimalHeatLoss(NormalCrucible)
+}
+
+func (s *Solver) Part2() (int, error) {
+	return s.minimalHeatLoss(UltraCrucible)
+}
@@ -1,4 +1,4 @@
-package main
+package day17
 
 import (
 	"bytes"
@@ -24,10 +24,85 @@ func Test(t *testing.T) {
 2546548887735
 4322674655533`)))
 
-	v := InputToBlockMap(input)
+	v, err := InputToBlockMap(input, advent.Strict)
+	assert.Nil(t, err)
 
-	assert.Equal(t, 2, v.Map[advent.Point{X: 0, Y: 0}])
+	assert.Equal(t, 2, v.Get(advent.Point{X: 0, Y: 0}))
 	assert.Equal(t, 13, v.Width)
 	assert.Equal(t, 13, v.Height)
-	assert.Equal(t, 3, v.Map[advent.Point{X: 12, Y: 12}])
+	assert.Equal(t, 3, v.Get(advent.Point{X: 12, Y: 12}))
+}
+
+func TestMinimalHeatLoss(t *testing.T) {
+	input := io.NopCloser(bytes.NewReader([]byte(`2413432311323
+3215453535623
+3255245654254
+3446585845452
+4546657867536
+1438598798454
+4457876987766
+3637877979653
+4654967986887
+4564679986453
+1224686865563
+2546548887735
+4322674655533`)))
+
+	v, err := InputToBlockMap(input, advent.Strict)
+	assert.Nil(t, err)
+
+	l, ok := v.MinimalHeatLoss(NormalCrucible)
+	assert.True(t, ok)
+	assert.Equal(t, 102, l)
+	l, ok = v.MinimalHeatLoss(UltraCrucible)
+	assert.True(t, ok)
+	assert.Equal(t, 94, l)
+}
+
+func TestMinimalHeatLossUltra(t *testing.T) {
+	input := io.NopCloser(bytes.NewReader([]byte(`111111111111
+999999999991
+999999999991
+999999999991
+999999999991`)))
+
+	v, err := InputToBlockMap(input, advent.Strict)
+	assert.Nil(t, err)
+
+	l, ok := v.MinimalHeatLoss(UltraCrucible)
+	assert.True(t, ok)
+	assert.Equal(t, 71, l)
+}
+
+func TestMinimalHeatLossSingleBlock(t *testing.T) {
+	v, err := InputToBlockMap(io.NopCloser(bytes.NewReader([]byte("7"))), advent.Strict)
+	assert.Nil(t, err)
+
+	for _, c := range []Crucible{NormalCrucible, UltraCrucible} {
+		l, ok := v.MinimalHeatLoss(c)
+		assert.True(t, ok)
+		assert.Equal(t, 0, l)
+	}
+}
+
+func TestMinimalHeatLossUnreachable(t *testing.T) {
+	s := &Solver{}
+	assert.Nil(t, s.Parse(io.NopCloser(bytes.NewReader([]byte("11111")))))
+
+	_, ok := s.bm.MinimalHeatLoss(NormalCrucible)
+	assert.False(t, ok)
+	_, err := s.Part1()
+	assert.EqualError(t, err, "no path from (0,0) to (4,0)")
+
+	v, err := s.Part2()
+	assert.Nil(t, err)
+	assert.Equal(t, 4, v)
+}
+
+func TestInputToBlockMapErrors(t *testing.T) {
+	_, err := InputToBlockMap(io.NopCloser(bytes.NewReader([]byte("123\n3x\n"))), advent.Strict)
+	assert.EqualError(t, err, "line 2: expected 3 cells, got 2")
+
+	_, err = InputToBlockMap(io.NopCloser(bytes.NewReader([]byte("123\n3x1\n"))), advent.Strict)
+	assert.ErrorContains(t, err, `line 2, column 2: parsing "x"`)
 }
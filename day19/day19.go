@@ -1,13 +1,14 @@
-package main
+package day19
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
-	"log"
-	"os"
 	"strconv"
 	"strings"
+
+	"github.com/pedrokiefer/adventofcode-2023/advent"
 )
 
 var (
@@ -264,7 +265,7 @@ func (pf *PartFilter) analyzeRecursive(w Workflow, pr PartRange, level int) []Pa
 			continue
 		}
 		if _, has := pf.Workflows[target]; !has {
-			log.Printf("missing workflow: %s", target)
+			advent.Warnf("missing workflow: %s", target)
 			continue
 		}
 		validPrs = append(validPrs, pf.analyzeRecursive(pf.Workflows[target], rr[0], level+1)...)
@@ -295,7 +296,7 @@ begin:
 			continue
 		}
 		if target == "" {
-			log.Printf("missing target!")
+			advent.Warnf("missing target!")
 			return false
 		}
 		if target == "R" {
@@ -305,7 +306,7 @@ begin:
 			return true
 		}
 		if _, has := pf.Workflows[target]; !has {
-			log.Printf("missing workflow: %s", target)
+			advent.Warnf("missing workflow: %s", target)
 			return false
 		}
 		w = pf.Workflows[target]
@@ -325,61 +326,93 @@ func (pf *PartFilter) FilterParts(parts []Part) []Part {
 	return accepted
 }
 
-func ToSteps(s string) []Step {
+var errInvalidStep = errors.New("invalid step")
+
+func ToSteps(s string) ([]Step, error) {
 	steps := []Step{}
+	column := 1
 	for _, v := range strings.Split(s, ",") {
-		if strings.Contains(v, "<") {
-			vStr := strings.Split(v, "<")
-			if len(vStr) != 2 {
-				log.Printf("invalid step: %s", v)
-				continue
-			}
-			targetStr := strings.Split(vStr[1], ":")
-			if len(targetStr) != 2 {
-				log.Printf("invalid step: %s", v)
-				continue
-			}
-			value, err := strconv.Atoi(targetStr[0])
-			if err != nil {
-				log.Printf("invalid step: %s", v)
-				continue
-			}
-			steps = append(steps, &StepLessThan{
-				Value:  value,
-				Field:  vStr[0],
-				Action: targetStr[1],
-			})
-		} else if strings.Contains(v, ">") {
-			vStr := strings.Split(v, ">")
-			if len(vStr) != 2 {
-				log.Printf("invalid step: %s", v)
-				continue
+		if strings.Contains(v, "<") || strings.Contains(v, ">") {
+			op := "<"
+			if strings.Contains(v, ">") {
+				op = ">"
 			}
-			targetStr := strings.Split(vStr[1], ":")
-			if len(targetStr) != 2 {
-				log.Printf("invalid step: %s", v)
-				continue
+			field, rest, _ := strings.Cut(v, op)
+			valueStr, action, ok := strings.Cut(rest, ":")
+			value, err := strconv.Atoi(valueStr)
+			if !ok || err != nil || strings.ContainsAny(rest, "<>") {
+				return nil, &advent.ParseError{Column: column, Text: v, Err: errInvalidStep}
 			}
-			value, err := strconv.Atoi(targetStr[0])
-			if err != nil {
-				log.Printf("invalid step: %s", v)
-				continue
+			if op == "<" {
+				steps = append(steps, &StepLessThan{
+					Value:  value,
+					Field:  field,
+					Action: action,
+				})
+			} else {
+				steps = append(steps, &StepGreaterThan{
+					Value:  value,
+					Field:  field,
+					Action: action,
+				})
 			}
-			steps = append(steps, &StepGreaterThan{
-				Value:  value,
-				Field:  vStr[0],
-				Action: targetStr[1],
-			})
 		} else {
 			steps = append(steps, &StepGoTo{
 				Target: v,
 			})
 		}
+		column += len(v) + 1
+	}
+	return steps, nil
+}
+
+func ReadWorkflow(s string) (Workflow, error) {
+	label, body, ok := strings.Cut(s, "{")
+	if !ok || !strings.HasSuffix(body, "}") {
+		return Workflow{}, errors.New("invalid workflow")
+	}
+	steps, err := ToSteps(strings.TrimSuffix(body, "}"))
+	if err != nil {
+		return Workflow{}, advent.Offset(err, len(label)+2)
+	}
+	return Workflow{
+		Label: label,
+		Steps: steps,
+	}, nil
+}
+
+func ReadPart(s string) (Part, error) {
+	if !strings.HasPrefix(s, "{") || !strings.HasSuffix(s, "}") {
+		return Part{}, errors.New("invalid part")
+	}
+	part := Part{}
+	column := 2
+	for _, vv := range strings.Split(s[1:len(s)-1], ",") {
+		k, v, _ := strings.Cut(vv, "=")
+		value, err := strconv.Atoi(v)
+		if err != nil {
+			return Part{}, &advent.ParseError{Column: column, Text: vv, Err: errors.New("invalid rating")}
+		}
+		switch k {
+		case "x":
+			part.X = value
+		case "m":
+			part.M = value
+		case "a":
+			part.A = value
+		case "s":
+			part.S = value
+		default:
+			return Part{}, &advent.ParseError{Column: column, Text: vv, Err: errors.New("unknown category")}
+		}
+		column += len(vv) + 1
 	}
-	return steps
+	return part, nil
 }
 
-func InputToPuzzleInput(input io.ReadCloser) (*PartFilter, []Part) {
+// InputToPuzzleInput reads the workflows and, after a blank line, the
+// parts. In Lenient mode the lines that cannot be read are skipped.
+func InputToPuzzleInput(input io.ReadCloser, mode advent.ParseMode) (*PartFilter, []Part, error) {
 	s := bufio.NewScanner(input)
 	defer input.Close()
 	p := "workflows"
@@ -387,7 +420,9 @@ func InputToPuzzleInput(input io.ReadCloser) (*PartFilter, []Part) {
 		Workflows: map[string]Workflow{},
 	}
 	parts := []Part{}
+	line := 0
 	for s.Scan() {
+		line++
 		l := s.Text()
 		if l == "" {
 			p = "parts"
@@ -395,45 +430,31 @@ func InputToPuzzleInput(input io.ReadCloser) (*PartFilter, []Part) {
 		}
 
 		if p == "workflows" {
-			label := strings.Split(l, "{")
-			if len(label) != 2 {
-				log.Printf("invalid workflow: %s", l)
+			wf, err := ReadWorkflow(l)
+			if err != nil {
+				if mode == advent.Strict {
+					return nil, nil, advent.AtLine(err, line, l)
+				}
 				continue
 			}
-			steps := ToSteps(strings.TrimSuffix(label[1], "}"))
-			wf := Workflow{
-				Label: label[0],
-				Steps: steps,
-			}
 			pf.Workflows[wf.Label] = wf
 			continue
 		} else if p == "parts" {
-			pp := strings.TrimPrefix(l, "{")
-			pp = strings.TrimSuffix(pp, "}")
-			part := Part{}
-			for _, vv := range strings.Split(pp, ",") {
-				kv := strings.Split(vv, "=")
-				value, err := strconv.Atoi(kv[1])
-				if err != nil {
-					log.Printf("Error parsing: %v", vv)
-					continue
-				}
-				switch kv[0] {
-				case "x":
-					part.X = value
-				case "m":
-					part.M = value
-				case "a":
-					part.A = value
-				case "s":
-					part.S = value
+			part, err := ReadPart(l)
+			if err != nil {
+				if mode == advent.Strict {
+					return nil, nil, advent.AtLine(err, line, l)
 				}
+				continue
 			}
 			parts = append(parts, part)
 		}
 
 	}
-	return pf, parts
+	if err := s.Err(); err != nil {
+		return nil, nil, err
+	}
+	return pf, parts, nil
 }
 
 func Sum(parts []Part) int {
@@ -444,22 +465,34 @@ func Sum(parts []Part) int {
 	return s
 }
 
-func main() {
-	filename := os.Args[1]
-	f, err := os.Open(filename)
+type Solver struct {
+	pf    *PartFilter
+	parts []Part
+}
+
+func init() {
+	advent.Register(19, func() advent.Solver { return &Solver{} })
+}
+
+func (s *Solver) Parse(input io.ReadCloser) error {
+	pf, parts, err := InputToPuzzleInput(input, advent.Strict)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
+	s.pf, s.parts = pf, parts
+	return nil
+}
 
-	pf, parts := InputToPuzzleInput(f)
-	accepted := pf.FilterParts(parts)
-	v := Sum(accepted)
-	fmt.Printf("Total parts: %d\n", v)
+func (s *Solver) Part1() (int, error) {
+	accepted := s.pf.FilterParts(s.parts)
+	return Sum(accepted), nil
+}
 
-	pr := pf.Analyze()
-	s := 0
+func (s *Solver) Part2() (int, error) {
+	pr := s.pf.Analyze()
+	total := 0
 	for _, pr := range pr {
-		s += pr.Value()
+		total += pr.Value()
 	}
-	fmt.Printf("Total combinations: %d\n", s)
+	return total, nil
 }
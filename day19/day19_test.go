@@ -1,10 +1,11 @@
-package main
+package day19
 
 import (
 	"bytes"
 	"io"
 	"testing"
 
+	"github.com/pedrokiefer/adventofcode-2023/advent"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -27,7 +28,8 @@ hdj{m>838:A,pv}
 {x=2461,m=1339,a=466,s=291}
 {x=2127,m=1623,a=2188,s=1013}`)))
 
-	pf, parts := InputToPuzzleInput(input)
+	pf, parts, err := InputToPuzzleInput(input, advent.Strict)
+	assert.Nil(t, err)
 
 	assert.Equal(t, 11, len(pf.Workflows))
 	assert.Equal(t, Workflow{
@@ -82,7 +84,8 @@ hdj{m>838:A,pv}
 {x=2461,m=1339,a=466,s=291}
 {x=2127,m=1623,a=2188,s=1013}`)))
 
-	pf, _ := InputToPuzzleInput(input)
+	pf, _, err := InputToPuzzleInput(input, advent.Strict)
+	assert.Nil(t, err)
 
 	assert.Equal(t, 11, len(pf.Workflows))
 
@@ -106,3 +109,30 @@ hdj{m>838:A,pv}
 	}
 	assert.Equal(t, 167409079868000, s)
 }
+
+func TestInputToPuzzleInputMode(t *testing.T) {
+	input := []byte(`px{a<2006:qkq,m>2090:A,rfg}
+pv{a>17x6:R,A}
+in{s<1351:px,pv}
+
+{x=787,m=2655,a=1222,s=2876}
+{x=1679,m=44,a=2067,q=496}
+{x=2036,m=264,a=79,s=2244}`)
+
+	pf, parts, err := InputToPuzzleInput(io.NopCloser(bytes.NewReader(input)), advent.Lenient)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(pf.Workflows))
+	assert.Equal(t, []Part{
+		{X: 787, M: 2655, A: 1222, S: 2876},
+		{X: 2036, M: 264, A: 79, S: 2244},
+	}, parts)
+
+	_, _, err = InputToPuzzleInput(io.NopCloser(bytes.NewReader(input)), advent.Strict)
+	assert.EqualError(t, err, `line 2, column 4: parsing "a>17x6:R": invalid step`)
+
+	_, err = ReadPart("{x=1679,m=44,a=2067,q=496}")
+	assert.EqualError(t, err, `column 21: parsing "q=496": unknown category`)
+
+	_, err = ReadWorkflow("px{a<2006:qkq,rfg")
+	assert.EqualError(t, err, "invalid workflow")
+}
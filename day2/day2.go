@@ -0,0 +1,245 @@
+package day2
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pedrokiefer/adventofcode-2023/advent"
+)
+
+type Game struct {
+	ID   int64
+	Sets []Set
+}
+
+// Set counts the cubes of each color shown at once, or held in a bag.
+// Colors that are not there have no entry.
+type Set map[string]int64
+
+const (
+	Red   = "red"
+	Green = "green"
+	Blue  = "blue"
+)
+
+func InputToGame(input io.ReadCloser, mode advent.ParseMode) ([]Game, error) {
+	results := []Game{}
+	defer input.Close()
+	err := advent.ParseLines(input, mode, func(l string) error {
+		g, err := ReadGame(l)
+		if err != nil {
+			return err
+		}
+		results = append(results, g)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func ReadGame(s string) (Game, error) {
+	g := Game{
+		Sets: []Set{},
+	}
+	head, body, ok := strings.Cut(s, ":")
+	if !ok {
+		return Game{}, fmt.Errorf("invalid game string: %s", s)
+	}
+
+	gameIDStr := strings.TrimPrefix(head, "Game ")
+	gameID, err := strconv.ParseInt(gameIDStr, 10, 64)
+	if err != nil {
+		return Game{}, &advent.ParseError{
+			Column: len(head) - len(gameIDStr) + 1,
+			Text:   gameIDStr,
+			Err:    errors.New("invalid game id"),
+		}
+	}
+
+	g.ID = gameID
+
+	// offset is the column right before the part being read
+	offset := len(head) + 1
+	for _, set := range strings.Split(body, ";") {
+		s := Set{}
+		colorOffset := offset
+		for _, cubes := range strings.Split(set, ",") {
+			column := colorOffset + len(cubes) - len(strings.TrimLeft(cubes, " ")) + 1
+			colorOffset += len(cubes) + 1
+			cubes = strings.TrimSpace(cubes)
+			color, count, err := ReadCubes(cubes)
+			if err == nil {
+				if _, ok := s[color]; ok {
+					err = fmt.Errorf("duplicated color %s", color)
+				}
+			}
+			if err != nil {
+				return g, &advent.ParseError{Column: column, Text: cubes, Err: err}
+			}
+			s[color] = count
+		}
+		g.Sets = append(g.Sets, s)
+		offset += len(set) + 1
+	}
+
+	return g, nil
+}
+
+// String writes the game back in the puzzle format, the colors of every
+// set sorted by name.
+func (g Game) String() string {
+	sets := make([]string, len(g.Sets))
+	for i, set := range g.Sets {
+		sets[i] = set.String()
+	}
+	return fmt.Sprintf("Game %d: %s", g.ID, strings.Join(sets, "; "))
+}
+
+// MarshalText fails for the games ReadGame could not read back: those with
+// no sets, an empty set, a negative count or a color that is not a single
+// word free of commas and semicolons.
+func (g Game) MarshalText() ([]byte, error) {
+	if len(g.Sets) == 0 {
+		return nil, fmt.Errorf("game %d has no sets", g.ID)
+	}
+	for _, set := range g.Sets {
+		if len(set) == 0 {
+			return nil, fmt.Errorf("game %d has an empty set", g.ID)
+		}
+		for color, count := range set {
+			if f := strings.Fields(color); len(f) != 1 || f[0] != color || strings.ContainsAny(color, ",;") {
+				return nil, fmt.Errorf("game %d has an invalid color %q", g.ID, color)
+			}
+			if count < 0 {
+				return nil, fmt.Errorf("game %d has %d %s cubes", g.ID, count, color)
+			}
+		}
+	}
+	return []byte(g.String()), nil
+}
+
+func (g *Game) UnmarshalText(text []byte) error {
+	v, err := ReadGame(string(text))
+	if err != nil {
+		return err
+	}
+	*g = v
+	return nil
+}
+
+func (s Set) String() string {
+	colors := make([]string, 0, len(s))
+	for color := range s {
+		colors = append(colors, color)
+	}
+	sort.Strings(colors)
+	cubes := make([]string, len(colors))
+	for i, color := range colors {
+		cubes[i] = fmt.Sprintf("%d %s", s[color], color)
+	}
+	return strings.Join(cubes, ", ")
+}
+
+// ReadCubes reads a "3 blue" count of cubes of one color.
+func ReadCubes(s string) (string, int64, error) {
+	f := strings.Fields(s)
+	if len(f) != 2 {
+		return "", -1, fmt.Errorf("invalid cubes: %s", s)
+	}
+	v, color := f[0], f[1]
+	val, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || val < 0 {
+		return "", -1, fmt.Errorf("invalid %s value: %s", color, v)
+	}
+	return color, val, nil
+}
+
+// Valid tells whether every set of the game could have been drawn from bag.
+// A bag without some color holds no cubes of it.
+func (g Game) Valid(bag Set) bool {
+	for _, set := range g.Sets {
+		for color, count := range set {
+			if count > bag[color] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// MinimalSet is the smallest bag the game could have been played with.
+func (g Game) MinimalSet() Set {
+	min := Set{}
+	for _, set := range g.Sets {
+		for color, count := range set {
+			if cur, ok := min[color]; !ok || count > cur {
+				min[color] = count
+			}
+		}
+	}
+	return min
+}
+
+// Power multiplies the counts of every color in the set, an empty set
+// having no power.
+func (s Set) Power() int64 {
+	if len(s) == 0 {
+		return 0
+	}
+	power := int64(1)
+	for _, count := range s {
+		power *= count
+	}
+	return power
+}
+
+// Bag is the bag of the puzzle, the one the games of part one are checked
+// against.
+var Bag = Set{Red: 12, Green: 13, Blue: 14}
+
+func Sum(l []int64) int64 {
+	var sum int64
+	for _, v := range l {
+		sum += v
+	}
+	return sum
+}
+
+type Solver struct {
+	games []Game
+}
+
+func init() {
+	advent.Register(2, func() advent.Solver { return &Solver{} })
+}
+
+func (s *Solver) Parse(input io.ReadCloser) error {
+	g, err := InputToGame(input, advent.Strict)
+	if err != nil {
+		return err
+	}
+	s.games = g
+	return nil
+}
+
+func (s *Solver) Part1() (int, error) {
+	gIds := []int64{}
+	for _, g := range Games(s.games).Possible(Bag) {
+		gIds = append(gIds, g.ID)
+	}
+	return int(Sum(gIds)), nil
+}
+
+func (s *Solver) Part2() (int, error) {
+	power := []int64{}
+	for _, g := range s.games {
+		power = append(power, g.MinimalSet().Power())
+	}
+	return int(Sum(power)), nil
+}
@@ -1,10 +1,13 @@
-package main
+package day2
 
 import (
 	"bytes"
+	"fmt"
 	"io"
+	"strings"
 	"testing"
 
+	"github.com/pedrokiefer/adventofcode-2023/advent"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -13,9 +16,9 @@ func TestCanReadAGame(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, int64(1), g.ID)
 	assert.Equal(t, []Set{
-		{Red: 4, Green: 0, Blue: 3},
+		{Red: 4, Blue: 3},
 		{Red: 1, Green: 2, Blue: 6},
-		{Red: 0, Green: 2, Blue: 0},
+		{Green: 2},
 	}, g.Sets)
 }
 
@@ -28,27 +31,28 @@ Game 4: 1 green, 3 red, 6 blue; 3 green, 6 red; 3 green, 15 blue, 14 red
 Game 5: 6 red, 1 blue, 3 green; 2 blue, 1 red, 2 green
 `)))
 
-	list := InputToGame(input)
+	list, err := InputToGame(input, advent.Strict)
+	assert.Nil(t, err)
 
 	assert.Equal(t, []Game{
 		{ID: 1, Sets: []Set{
-			{Red: 4, Green: 0, Blue: 3},
+			{Red: 4, Blue: 3},
 			{Red: 1, Green: 2, Blue: 6},
-			{Red: 0, Green: 2, Blue: 0},
+			{Green: 2},
 		}},
 		{ID: 2, Sets: []Set{
-			{Red: 0, Green: 2, Blue: 1},
+			{Green: 2, Blue: 1},
 			{Red: 1, Green: 3, Blue: 4},
-			{Red: 0, Green: 1, Blue: 1},
+			{Green: 1, Blue: 1},
 		}},
 		{ID: 3, Sets: []Set{
 			{Red: 20, Green: 8, Blue: 6},
 			{Red: 4, Green: 13, Blue: 5},
-			{Red: 1, Green: 5, Blue: 0},
+			{Red: 1, Green: 5},
 		}},
 		{ID: 4, Sets: []Set{
 			{Red: 3, Green: 1, Blue: 6},
-			{Red: 6, Green: 3, Blue: 0},
+			{Red: 6, Green: 3},
 			{Red: 14, Green: 3, Blue: 15},
 		}},
 		{ID: 5, Sets: []Set{
@@ -63,9 +67,9 @@ func TestCanGetMinimalSet(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, int64(1), g.ID)
 	assert.Equal(t, []Set{
-		{Red: 4, Green: 0, Blue: 3},
+		{Red: 4, Blue: 3},
 		{Red: 1, Green: 2, Blue: 6},
-		{Red: 0, Green: 2, Blue: 0},
+		{Green: 2},
 	}, g.Sets)
 	assert.Equal(t, Set{Red: 4, Green: 2, Blue: 6}, g.MinimalSet())
 	assert.Equal(t, int64(48), g.MinimalSet().Power())
@@ -78,7 +82,211 @@ func TestCanGetMinimalSet2(t *testing.T) {
 	assert.Equal(t, []Set{
 		{Red: 20, Green: 8, Blue: 6},
 		{Red: 4, Green: 13, Blue: 5},
-		{Red: 1, Green: 5, Blue: 0},
+		{Red: 1, Green: 5},
 	}, g.Sets)
 	assert.Equal(t, Set{Red: 20, Green: 13, Blue: 6}, g.MinimalSet())
 }
+
+func TestReadGameErrors(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		err   string
+	}{
+		{"Game 1 3 blue", "invalid game string: Game 1 3 blue"},
+		{"Game x: 3 blue", `column 6: parsing "x": invalid game id`},
+		{"Game 1: 3 blue, 4 red; 1 red, x green", `column 31: parsing "x green": invalid green value: x`},
+		{"Game 1: 3 blue;  2 purple", ``},
+		{"Game 1: 3 blue;  2 dark purple", `column 18: parsing "2 dark purple": invalid cubes: 2 dark purple`},
+		{"Game 1: 3 blue, 1 red, 2 blue", `column 24: parsing "2 blue": duplicated color blue`},
+	} {
+		_, err := ReadGame(tc.input)
+		if tc.err == "" {
+			assert.Nil(t, err, tc.input)
+			continue
+		}
+		assert.EqualError(t, err, tc.err, tc.input)
+	}
+}
+
+func TestInputToGameMode(t *testing.T) {
+	input := []byte(`Game 1: 3 blue, 4 red
+Game 2: 1 blue, 2 green green
+Game 3: 8 green, 6 blue
+`)
+
+	list, err := InputToGame(io.NopCloser(bytes.NewReader(input)), advent.Lenient)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(list))
+	assert.Equal(t, int64(3), list[1].ID)
+
+	_, err = InputToGame(io.NopCloser(bytes.NewReader(input)), advent.Strict)
+	assert.EqualError(t, err, `line 2, column 17: parsing "2 green green": invalid cubes: 2 green green`)
+}
+
+func TestArbitraryColors(t *testing.T) {
+	g, err := ReadGame("Game 7: 2 yellow, 1 purple; 5 yellow, 3 red; 0 purple")
+	assert.Nil(t, err)
+	assert.Equal(t, []Set{
+		{"yellow": 2, "purple": 1},
+		{"yellow": 5, Red: 3},
+		{"purple": 0},
+	}, g.Sets)
+	assert.Equal(t, Set{"yellow": 5, "purple": 1, Red: 3}, g.MinimalSet())
+	assert.Equal(t, int64(15), g.MinimalSet().Power())
+
+	assert.True(t, g.Valid(Set{"yellow": 5, "purple": 1, Red: 3}))
+	assert.False(t, g.Valid(Set{"yellow": 4, "purple": 1, Red: 3}))
+	// colors missing from the bag have no cubes
+	assert.False(t, g.Valid(Bag))
+	assert.True(t, g.Valid(Set{"yellow": 9, "purple": 9, Red: 9, Blue: 9}))
+
+	g, err = ReadGame("Game 8: 0 red, 4 blue")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), g.MinimalSet().Power())
+	assert.Equal(t, int64(0), Set{}.Power())
+}
+
+func exampleGames(t *testing.T) Games {
+	input := io.NopCloser(bytes.NewReader([]byte(`Game 1: 3 blue, 4 red; 1 red, 2 green, 6 blue; 2 green
+Game 2: 1 blue, 2 green; 3 green, 4 blue, 1 red; 1 green, 1 blue
+Game 3: 8 green, 6 blue, 20 red; 5 blue, 4 red, 13 green; 5 green, 1 red
+Game 4: 1 green, 3 red, 6 blue; 3 green, 6 red; 3 green, 15 blue, 14 red
+Game 5: 6 red, 1 blue, 3 green; 2 blue, 1 red, 2 green`)))
+	list, err := InputToGame(input, advent.Strict)
+	assert.Nil(t, err)
+	return Games(list)
+}
+
+func ids(gs Games) []int64 {
+	result := []int64{}
+	for _, g := range gs {
+		result = append(result, g.ID)
+	}
+	return result
+}
+
+func TestDistributions(t *testing.T) {
+	gs := exampleGames(t)
+	assert.Equal(t, []string{Blue, Green, Red}, gs.Colors())
+
+	d := gs.Distributions()
+	assert.Equal(t, Distribution{
+		Color:  Red,
+		Counts: map[int64]int{1: 1, 4: 1, 6: 1, 14: 1, 20: 1},
+		Min:    1,
+		Max:    20,
+		Mean:   9,
+	}, d[Red])
+	assert.Equal(t, map[int64]int{2: 1, 3: 3, 13: 1}, d[Green].Counts)
+
+	gs = append(gs, Game{ID: 6, Sets: []Set{{"yellow": 2}}})
+	d = gs.Distributions()
+	assert.Equal(t, map[int64]int{0: 5, 2: 1}, d["yellow"].Counts)
+	assert.Equal(t, int64(0), d[Red].Min)
+}
+
+func TestSmallestBag(t *testing.T) {
+	gs := exampleGames(t)
+	assert.Equal(t, Set{Blue: 15, Green: 13, Red: 20}, gs.SmallestBag(100))
+	assert.Equal(t, Set{Blue: 6, Green: 3, Red: 6}, gs.SmallestBag(60))
+	assert.Equal(t, Set{Blue: 4, Green: 3, Red: 1}, gs.SmallestBag(20))
+	assert.Equal(t, Set{Blue: 0, Green: 0, Red: 0}, gs.SmallestBag(0))
+	assert.Equal(t, Set{}, Games{}.SmallestBag(50))
+
+	for _, p := range []float64{20, 40, 60, 80, 100} {
+		bag := gs.SmallestBag(p)
+		assert.GreaterOrEqual(t, float64(len(gs.Possible(bag))), p/100*float64(len(gs)))
+	}
+}
+
+func TestLowered(t *testing.T) {
+	gs := exampleGames(t)
+	assert.Equal(t, []int64{1, 2, 5}, ids(gs.Possible(Bag)))
+	assert.Equal(t, []int64{5}, ids(gs.Lowered(Bag, Red, 5)))
+	assert.Equal(t, []int64{2, 5}, ids(gs.Lowered(Bag, Green, 2)))
+	assert.Equal(t, []int64{}, ids(gs.Lowered(Bag, Blue, 20)))
+	assert.Equal(t, 14, int(Bag[Blue]))
+}
+
+func TestGameText(t *testing.T) {
+	g, err := ReadGame("Game 3: 8 green, 6 blue, 20 red; 5 blue, 4 red, 13 green; 5 green, 1 red")
+	assert.Nil(t, err)
+	assert.Equal(t, "Game 3: 6 blue, 8 green, 20 red; 5 blue, 13 green, 4 red; 5 green, 1 red", g.String())
+
+	b, err := g.MarshalText()
+	assert.Nil(t, err)
+	g2 := Game{}
+	assert.Nil(t, g2.UnmarshalText(b))
+	assert.Equal(t, g, g2)
+
+	assert.EqualError(t, g2.UnmarshalText([]byte("Game 4: 1 red, x blue")), `column 16: parsing "x blue": invalid blue value: x`)
+	assert.Equal(t, g, g2)
+
+	for _, tc := range []struct {
+		game Game
+		err  string
+	}{
+		{Game{ID: 5}, "game 5 has no sets"},
+		{Game{ID: 5, Sets: []Set{{Red: 1}, {}}}, "game 5 has an empty set"},
+		{Game{ID: 5, Sets: []Set{{"dark red": 1}}}, `game 5 has an invalid color "dark red"`},
+		{Game{ID: 5, Sets: []Set{{"red;": 1}}}, `game 5 has an invalid color "red;"`},
+		{Game{ID: 5, Sets: []Set{{Red: -1}}}, "game 5 has -1 red cubes"},
+	} {
+		_, err := tc.game.MarshalText()
+		assert.EqualError(t, err, tc.err)
+	}
+}
+
+func FuzzGameRoundTrip(f *testing.F) {
+	f.Add("Game 1: 3 blue, 4 red; 1 red, 2 green, 6 blue; 2 green")
+	f.Add("Game 7: 2 yellow, 1 purple; 5 yellow, 3 red; 0 purple")
+	f.Add("Game -2:1 a:b")
+	f.Add("12: +3 blue")
+	f.Fuzz(func(t *testing.T, s string) {
+		g, err := ReadGame(s)
+		if err != nil {
+			return
+		}
+		g2, err := ReadGame(g.String())
+		if err != nil {
+			t.Fatalf("%q formatted as %q: %v", s, g.String(), err)
+		}
+		assert.Equal(t, g, g2)
+		assert.Equal(t, g.String(), g2.String())
+	})
+}
+
+func FuzzGameFormat(f *testing.F) {
+	f.Add(int64(1), "blue", int64(3), "red", int64(4), uint8(2))
+	f.Add(int64(-9), "púrpura", int64(0), "x:y", int64(1<<62), uint8(1))
+	f.Fuzz(func(t *testing.T, id int64, c1 string, n1 int64, c2 string, n2 int64, sets uint8) {
+		for _, c := range []string{c1, c2} {
+			if f := strings.Fields(c); len(f) != 1 || f[0] != c || strings.ContainsAny(c, ",;") {
+				t.Skip()
+			}
+		}
+		if n1 < 0 || n2 < 0 {
+			t.Skip()
+		}
+		g := Game{ID: id, Sets: []Set{}}
+		for i := 0; i < int(sets%8); i++ {
+			set := Set{c1: n1}
+			if i%3 != 0 {
+				set[c2] = n2
+			}
+			g.Sets = append(g.Sets, set)
+		}
+
+		g2 := Game{}
+		b, err := g.MarshalText()
+		if len(g.Sets) == 0 {
+			assert.EqualError(t, err, fmt.Sprintf("game %d has no sets", id))
+			return
+		}
+		assert.Nil(t, err)
+		if err := g2.UnmarshalText(b); err != nil {
+			t.Fatalf("%q: %v", b, err)
+		}
+		assert.Equal(t, g, g2)
+	})
+}
@@ -0,0 +1,176 @@
+package day2
+
+import (
+	"math"
+	"sort"
+)
+
+// Games is a game log, with queries to explore other bags than the one of
+// the puzzle.
+type Games []Game
+
+// Colors returns every color shown in the games, sorted.
+func (gs Games) Colors() []string {
+	seen := map[string]bool{}
+	colors := []string{}
+	for _, g := range gs {
+		for _, set := range g.Sets {
+			for color := range set {
+				if !seen[color] {
+					seen[color] = true
+					colors = append(colors, color)
+				}
+			}
+		}
+	}
+	sort.Strings(colors)
+	return colors
+}
+
+// Distribution is how many cubes of a color the games need: Counts maps
+// every count to the number of games whose minimal set has that many.
+// Games that never show the color need none of it.
+type Distribution struct {
+	Color  string
+	Counts map[int64]int
+	Min    int64
+	Max    int64
+	Mean   float64
+}
+
+func (gs Games) Distributions() map[string]Distribution {
+	result := map[string]Distribution{}
+	if len(gs) == 0 {
+		return result
+	}
+	minimal := gs.minimalSets()
+	for _, color := range gs.Colors() {
+		d := Distribution{
+			Color:  color,
+			Counts: map[int64]int{},
+			Min:    math.MaxInt64,
+		}
+		sum := int64(0)
+		for _, m := range minimal {
+			v := m[color]
+			d.Counts[v]++
+			d.Min = min(d.Min, v)
+			d.Max = max(d.Max, v)
+			sum += v
+		}
+		d.Mean = float64(sum) / float64(len(gs))
+		result[color] = d
+	}
+	return result
+}
+
+func (gs Games) minimalSets() []Set {
+	result := make([]Set, len(gs))
+	for i, g := range gs {
+		result[i] = g.MinimalSet()
+	}
+	return result
+}
+
+// Possible returns the games that could have been played with bag.
+func (gs Games) Possible(bag Set) Games {
+	result := Games{}
+	for _, g := range gs {
+		if g.Valid(bag) {
+			result = append(result, g)
+		}
+	}
+	return result
+}
+
+// Lowered returns the games possible with bag that become impossible once
+// it only holds count cubes of color.
+func (gs Games) Lowered(bag Set, color string, count int64) Games {
+	lowered := Set{}
+	for c, v := range bag {
+		lowered[c] = v
+	}
+	lowered[color] = min(count, bag[color])
+
+	result := Games{}
+	for _, g := range gs {
+		if g.Valid(bag) && !g.Valid(lowered) {
+			result = append(result, g)
+		}
+	}
+	return result
+}
+
+// SmallestBag returns the bag with the fewest cubes that makes at least
+// percent of the games possible, with an entry for every color of the log.
+// The count of each color in such a bag is one of the counts the games
+// need, so those are searched color by color, the last color being picked
+// directly from the games that still fit.
+func (gs Games) SmallestBag(percent float64) Set {
+	colors := gs.Colors()
+	bag := Set{}
+	for _, color := range colors {
+		bag[color] = 0
+	}
+	need := int(math.Ceil(percent / 100 * float64(len(gs))))
+	need = min(need, len(gs))
+	if need <= 0 || len(colors) == 0 {
+		return bag
+	}
+
+	minimal := gs.minimalSets()
+	candidates := make([][]int64, len(colors))
+	for i, color := range colors {
+		seen := map[int64]bool{}
+		for _, m := range minimal {
+			if !seen[m[color]] {
+				seen[m[color]] = true
+				candidates[i] = append(candidates[i], m[color])
+			}
+		}
+		sort.Slice(candidates[i], func(a, b int) bool {
+			return candidates[i][a] < candidates[i][b]
+		})
+	}
+
+	var best Set
+	bestTotal := int64(math.MaxInt64)
+	var search func(c int, fit []Set, total int64)
+	search = func(c int, fit []Set, total int64) {
+		if len(fit) < need || total >= bestTotal {
+			return
+		}
+		color := colors[c]
+		if c == len(colors)-1 {
+			counts := make([]int64, len(fit))
+			for i, m := range fit {
+				counts[i] = m[color]
+			}
+			sort.Slice(counts, func(a, b int) bool { return counts[a] < counts[b] })
+			if v := counts[need-1]; total+v < bestTotal {
+				bestTotal = total + v
+				best = Set{}
+				for k, v := range bag {
+					best[k] = v
+				}
+				best[color] = v
+			}
+			return
+		}
+		for _, v := range candidates[c] {
+			if total+v >= bestTotal {
+				break
+			}
+			sub := []Set{}
+			for _, m := range fit {
+				if m[color] <= v {
+					sub = append(sub, m)
+				}
+			}
+			bag[color] = v
+			search(c+1, sub, total+v)
+		}
+	}
+	search(0, minimal, 0)
+	return best
+}
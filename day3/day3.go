@@ -0,0 +1,330 @@
+package day3
+
+import (
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pedrokiefer/adventofcode-2023/advent"
+)
+
+type Element struct {
+	Value    int64
+	Symbol   bool
+	SymbolC  rune
+	StartPos advent.Point
+	EndPos   advent.Point
+	Valid    bool
+}
+
+func (e Element) String() string {
+	if e.Symbol {
+		return string(e.SymbolC) + " " + e.StartPos.String() + " " + e.EndPos.String()
+	}
+	return strconv.FormatInt(e.Value, 10) + " " + e.StartPos.String() + " " + e.EndPos.String()
+}
+
+// Rect is the rectangle of cells from Min to Max, both included.
+type Rect struct {
+	Min advent.Point
+	Max advent.Point
+}
+
+// Grow returns the rectangle with n more cells on every side.
+func (r Rect) Grow(n int) Rect {
+	d := advent.Point{X: n, Y: n}
+	return Rect{Min: r.Min.Sub(d), Max: r.Max.Add(d)}
+}
+
+func (e *Element) Rect() Rect {
+	return Rect{Min: e.StartPos, Max: e.EndPos}
+}
+
+// Gear is a symbol matched by a GearRule, with the parts next to it.
+type Gear struct {
+	Symbol   rune
+	Position advent.Point
+	Parts    []*Element
+	Ratio    int64
+}
+
+func (g Gear) String() string {
+	return strconv.FormatInt(g.Ratio, 10)
+}
+
+// Schematic keeps the numbers and symbols of an engine schematic as spans
+// on the row they are written on, sorted by column, so the elements in a
+// rectangle are found with a binary search on each of its rows.
+type Schematic struct {
+	Width   int
+	Height  int
+	Numbers []*Element
+	Symbols []*Element
+	numbers [][]*Element
+	symbols [][]*Element
+}
+
+func isDigit(c rune) bool {
+	return c >= '0' && c <= '9'
+}
+
+// InputToSchematic reads the engine schematic. In Lenient mode the rows
+// that cannot be read and the numbers too big for an int64 are skipped.
+func InputToSchematic(input io.ReadCloser, mode advent.ParseMode) (*Schematic, error) {
+	defer input.Close()
+	g, err := advent.ParseGrid(input, mode, func(c rune) (rune, error) {
+		return c, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s := &Schematic{
+		Width:   g.Width,
+		Height:  g.Height,
+		Numbers: []*Element{},
+		Symbols: []*Element{},
+		numbers: make([][]*Element, g.Height),
+		symbols: make([][]*Element, g.Height),
+	}
+	for y := 0; y < g.Height; y++ {
+		row := g.Row(y)
+		for x := 0; x < len(row); {
+			c := row[x]
+			switch {
+			case isDigit(c):
+				start := x
+				for x < len(row) && isDigit(row[x]) {
+					x++
+				}
+				v, err := strconv.ParseInt(string(row[start:x]), 10, 64)
+				if err != nil {
+					if mode == advent.Strict {
+						line, column := g.Source(advent.Point{X: start, Y: y})
+						return nil, &advent.ParseError{Line: line, Column: column, Text: string(row[start:x]), Err: err}
+					}
+					continue
+				}
+				e := &Element{
+					Value:    v,
+					StartPos: advent.Point{X: start, Y: y},
+					EndPos:   advent.Point{X: x - 1, Y: y},
+				}
+				s.Numbers = append(s.Numbers, e)
+				s.numbers[y] = append(s.numbers[y], e)
+			case c != '.':
+				p := advent.Point{X: x, Y: y}
+				e := &Element{
+					Symbol:   true,
+					SymbolC:  c,
+					StartPos: p,
+					EndPos:   p,
+				}
+				s.Symbols = append(s.Symbols, e)
+				s.symbols[y] = append(s.symbols[y], e)
+				x++
+			default:
+				x++
+			}
+		}
+	}
+	return s, nil
+}
+
+// NumberAt returns the number written over p, if any.
+func (s *Schematic) NumberAt(p advent.Point) *Element {
+	n := s.NumbersIn(Rect{Min: p, Max: p})
+	if len(n) == 0 {
+		return nil
+	}
+	return n[0]
+}
+
+// NumbersIn returns the numbers with at least one digit in r, in reading
+// order.
+func (s *Schematic) NumbersIn(r Rect) []*Element {
+	return in(s.numbers, r)
+}
+
+func (s *Schematic) SymbolsIn(r Rect) []*Element {
+	return in(s.symbols, r)
+}
+
+// Touching returns the numbers in r or next to it, diagonals included.
+func (s *Schematic) Touching(r Rect) []*Element {
+	return s.NumbersIn(r.Grow(1))
+}
+
+// Neighbors returns the numbers next to e, diagonals included.
+func (s *Schematic) Neighbors(e *Element) []*Element {
+	result := []*Element{}
+	for _, n := range s.Touching(e.Rect()) {
+		if n != e {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+func in(rows [][]*Element, r Rect) []*Element {
+	result := []*Element{}
+	for y := max(r.Min.Y, 0); y <= min(r.Max.Y, len(rows)-1); y++ {
+		row := rows[y]
+		i := sort.Search(len(row), func(i int) bool {
+			return row[i].EndPos.X >= r.Min.X
+		})
+		for ; i < len(row) && row[i].StartPos.X <= r.Max.X; i++ {
+			result = append(result, row[i])
+		}
+	}
+	return result
+}
+
+// CheckValid marks the numbers next to a symbol as valid part numbers.
+func CheckValid(s *Schematic) {
+	for _, sym := range s.Symbols {
+		for _, e := range s.Touching(sym.Rect()) {
+			e.Valid = true
+		}
+	}
+}
+
+// PartCount is how many parts a gear may have, from Min to Max.
+type PartCount struct {
+	Min int
+	Max int
+}
+
+func Exactly(n int) PartCount {
+	return PartCount{Min: n, Max: n}
+}
+
+func AtLeast(n int) PartCount {
+	return PartCount{Min: n, Max: math.MaxInt}
+}
+
+func AtMost(n int) PartCount {
+	return PartCount{Min: 0, Max: n}
+}
+
+func (c PartCount) Match(n int) bool {
+	return n >= c.Min && n <= c.Max
+}
+
+// Aggregation turns the values of the parts of a gear into its ratio. All
+// of them give zero for a gear without parts.
+type Aggregation func(values []int64) int64
+
+var (
+	Product Aggregation = func(values []int64) int64 {
+		if len(values) == 0 {
+			return 0
+		}
+		p := int64(1)
+		for _, v := range values {
+			p *= v
+		}
+		return p
+	}
+	Sum Aggregation = func(values []int64) int64 {
+		s := int64(0)
+		for _, v := range values {
+			s += v
+		}
+		return s
+	}
+	Max Aggregation = func(values []int64) int64 {
+		m := int64(0)
+		for _, v := range values {
+			m = max(m, v)
+		}
+		return m
+	}
+)
+
+// GearRule tells which symbols are gears: one of Symbols, or any symbol
+// when empty, next to a number of parts that matches Parts. Their ratio is
+// the Aggregate of the parts values, their Product when nil.
+type GearRule struct {
+	Symbols   string
+	Parts     PartCount
+	Aggregate Aggregation
+}
+
+// PuzzleGears is the rule of the puzzle: a '*' next to exactly two parts,
+// with the product of their values as ratio.
+var PuzzleGears = GearRule{
+	Symbols:   "*",
+	Parts:     Exactly(2),
+	Aggregate: Product,
+}
+
+// FindGears returns the symbols matching rule in reading order, their parts
+// in reading order too.
+func FindGears(s *Schematic, rule GearRule) []*Gear {
+	aggregate := rule.Aggregate
+	if aggregate == nil {
+		aggregate = Product
+	}
+	result := []*Gear{}
+	for _, sym := range s.Symbols {
+		if rule.Symbols != "" && !strings.ContainsRune(rule.Symbols, sym.SymbolC) {
+			continue
+		}
+		parts := s.Touching(sym.Rect())
+		if !rule.Parts.Match(len(parts)) {
+			continue
+		}
+		values := make([]int64, len(parts))
+		for i, p := range parts {
+			values[i] = p.Value
+		}
+		result = append(result, &Gear{
+			Symbol:   sym.SymbolC,
+			Position: sym.StartPos,
+			Parts:    parts,
+			Ratio:    aggregate(values),
+		})
+	}
+	return result
+}
+
+type Solver struct {
+	s *Schematic
+}
+
+func init() {
+	advent.Register(3, func() advent.Solver { return &Solver{} })
+}
+
+func (s *Solver) Parse(input io.ReadCloser) error {
+	schematic, err := InputToSchematic(input, advent.Strict)
+	if err != nil {
+		return err
+	}
+	s.s = schematic
+	return nil
+}
+
+func (s *Solver) Part1() (int, error) {
+	CheckValid(s.s)
+
+	sum := int64(0)
+	for _, e := range s.s.Numbers {
+		if e.Valid {
+			sum += e.Value
+		}
+	}
+	return int(sum), nil
+}
+
+func (s *Solver) Part2() (int, error) {
+	gears := FindGears(s.s, PuzzleGears)
+
+	gearSum := int64(0)
+	for _, g := range gears {
+		gearSum += g.Ratio
+	}
+	return int(gearSum), nil
+}
@@ -1,4 +1,4 @@
-package main
+package day3
 
 import (
 	"bytes"
@@ -6,6 +6,7 @@ import (
 	"io"
 	"testing"
 
+	"github.com/pedrokiefer/adventofcode-2023/advent"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,35 +22,37 @@ func TestParsePuzzle(t *testing.T) {
 ...$.*....
 .664.598..`)))
 
-	m, symbols, parts := InputToPuzzleMap(input)
+	m, err := InputToSchematic(input, advent.Strict)
+	assert.Nil(t, err)
+	symbols, parts := m.Symbols, m.Numbers
 
 	fmt.Printf("%v\n", symbols)
 
-	assert.Equal(t, m[Position{X: 0, Y: 0}], &Element{Value: 467, Symbol: false, StartPos: Position{X: 0, Y: 0}, EndPos: Position{X: 2, Y: 0}})
+	assert.Equal(t, m.NumberAt(advent.Point{X: 1, Y: 0}), &Element{Value: 467, Symbol: false, StartPos: advent.Point{X: 0, Y: 0}, EndPos: advent.Point{X: 2, Y: 0}})
 
 	assert.Equal(t, []*Element{
-		{Symbol: true, SymbolC: '*', StartPos: Position{X: 3, Y: 1}, EndPos: Position{X: 3, Y: 1}},
-		{Symbol: true, SymbolC: '#', StartPos: Position{X: 6, Y: 3}, EndPos: Position{X: 6, Y: 3}},
-		{Symbol: true, SymbolC: '*', StartPos: Position{X: 3, Y: 4}, EndPos: Position{X: 3, Y: 4}},
-		{Symbol: true, SymbolC: '+', StartPos: Position{X: 5, Y: 5}, EndPos: Position{X: 5, Y: 5}},
-		{Symbol: true, SymbolC: '$', StartPos: Position{X: 3, Y: 8}, EndPos: Position{X: 3, Y: 8}},
-		{Symbol: true, SymbolC: '*', StartPos: Position{X: 5, Y: 8}, EndPos: Position{X: 5, Y: 8}},
+		{Symbol: true, SymbolC: '*', StartPos: advent.Point{X: 3, Y: 1}, EndPos: advent.Point{X: 3, Y: 1}},
+		{Symbol: true, SymbolC: '#', StartPos: advent.Point{X: 6, Y: 3}, EndPos: advent.Point{X: 6, Y: 3}},
+		{Symbol: true, SymbolC: '*', StartPos: advent.Point{X: 3, Y: 4}, EndPos: advent.Point{X: 3, Y: 4}},
+		{Symbol: true, SymbolC: '+', StartPos: advent.Point{X: 5, Y: 5}, EndPos: advent.Point{X: 5, Y: 5}},
+		{Symbol: true, SymbolC: '$', StartPos: advent.Point{X: 3, Y: 8}, EndPos: advent.Point{X: 3, Y: 8}},
+		{Symbol: true, SymbolC: '*', StartPos: advent.Point{X: 5, Y: 8}, EndPos: advent.Point{X: 5, Y: 8}},
 	}, symbols)
 
 	assert.Equal(t, []*Element{
-		{Value: 467, Symbol: false, StartPos: Position{X: 0, Y: 0}, EndPos: Position{X: 2, Y: 0}},
-		{Value: 114, Symbol: false, StartPos: Position{X: 5, Y: 0}, EndPos: Position{X: 7, Y: 0}},
-		{Value: 35, Symbol: false, StartPos: Position{X: 2, Y: 2}, EndPos: Position{X: 3, Y: 2}},
-		{Value: 633, Symbol: false, StartPos: Position{X: 6, Y: 2}, EndPos: Position{X: 8, Y: 2}},
-		{Value: 617, Symbol: false, StartPos: Position{X: 0, Y: 4}, EndPos: Position{X: 2, Y: 4}},
-		{Value: 58, Symbol: false, StartPos: Position{X: 7, Y: 5}, EndPos: Position{X: 8, Y: 5}},
-		{Value: 592, Symbol: false, StartPos: Position{X: 2, Y: 6}, EndPos: Position{X: 4, Y: 6}},
-		{Value: 755, Symbol: false, StartPos: Position{X: 6, Y: 7}, EndPos: Position{X: 8, Y: 7}},
-		{Value: 664, Symbol: false, StartPos: Position{X: 1, Y: 9}, EndPos: Position{X: 3, Y: 9}},
-		{Value: 598, Symbol: false, StartPos: Position{X: 5, Y: 9}, EndPos: Position{X: 7, Y: 9}},
+		{Value: 467, Symbol: false, StartPos: advent.Point{X: 0, Y: 0}, EndPos: advent.Point{X: 2, Y: 0}},
+		{Value: 114, Symbol: false, StartPos: advent.Point{X: 5, Y: 0}, EndPos: advent.Point{X: 7, Y: 0}},
+		{Value: 35, Symbol: false, StartPos: advent.Point{X: 2, Y: 2}, EndPos: advent.Point{X: 3, Y: 2}},
+		{Value: 633, Symbol: false, StartPos: advent.Point{X: 6, Y: 2}, EndPos: advent.Point{X: 8, Y: 2}},
+		{Value: 617, Symbol: false, StartPos: advent.Point{X: 0, Y: 4}, EndPos: advent.Point{X: 2, Y: 4}},
+		{Value: 58, Symbol: false, StartPos: advent.Point{X: 7, Y: 5}, EndPos: advent.Point{X: 8, Y: 5}},
+		{Value: 592, Symbol: false, StartPos: advent.Point{X: 2, Y: 6}, EndPos: advent.Point{X: 4, Y: 6}},
+		{Value: 755, Symbol: false, StartPos: advent.Point{X: 6, Y: 7}, EndPos: advent.Point{X: 8, Y: 7}},
+		{Value: 664, Symbol: false, StartPos: advent.Point{X: 1, Y: 9}, EndPos: advent.Point{X: 3, Y: 9}},
+		{Value: 598, Symbol: false, StartPos: advent.Point{X: 5, Y: 9}, EndPos: advent.Point{X: 7, Y: 9}},
 	}, parts)
 
-	CheckValid(m, symbols)
+	CheckValid(m)
 
 	sum := int64(0)
 	for _, e := range parts {
@@ -73,44 +76,181 @@ func TestFindGears(t *testing.T) {
 ...$.*....
 .664.598..`)))
 
-	m, symbols, parts := InputToPuzzleMap(input)
+	m, err := InputToSchematic(input, advent.Strict)
+	assert.Nil(t, err)
+	symbols, parts := m.Symbols, m.Numbers
 
-	assert.Equal(t, m[Position{X: 0, Y: 0}], &Element{Value: 467, Symbol: false, StartPos: Position{X: 0, Y: 0}, EndPos: Position{X: 2, Y: 0}})
+	assert.Equal(t, m.NumberAt(advent.Point{X: 1, Y: 0}), &Element{Value: 467, Symbol: false, StartPos: advent.Point{X: 0, Y: 0}, EndPos: advent.Point{X: 2, Y: 0}})
 
 	assert.Equal(t, []*Element{
-		{Symbol: true, SymbolC: '*', StartPos: Position{X: 3, Y: 1}, EndPos: Position{X: 3, Y: 1}},
-		{Symbol: true, SymbolC: '#', StartPos: Position{X: 6, Y: 3}, EndPos: Position{X: 6, Y: 3}},
-		{Symbol: true, SymbolC: '*', StartPos: Position{X: 3, Y: 4}, EndPos: Position{X: 3, Y: 4}},
-		{Symbol: true, SymbolC: '+', StartPos: Position{X: 5, Y: 5}, EndPos: Position{X: 5, Y: 5}},
-		{Symbol: true, SymbolC: '$', StartPos: Position{X: 3, Y: 8}, EndPos: Position{X: 3, Y: 8}},
-		{Symbol: true, SymbolC: '*', StartPos: Position{X: 5, Y: 8}, EndPos: Position{X: 5, Y: 8}},
+		{Symbol: true, SymbolC: '*', StartPos: advent.Point{X: 3, Y: 1}, EndPos: advent.Point{X: 3, Y: 1}},
+		{Symbol: true, SymbolC: '#', StartPos: advent.Point{X: 6, Y: 3}, EndPos: advent.Point{X: 6, Y: 3}},
+		{Symbol: true, SymbolC: '*', StartPos: advent.Point{X: 3, Y: 4}, EndPos: advent.Point{X: 3, Y: 4}},
+		{Symbol: true, SymbolC: '+', StartPos: advent.Point{X: 5, Y: 5}, EndPos: advent.Point{X: 5, Y: 5}},
+		{Symbol: true, SymbolC: '$', StartPos: advent.Point{X: 3, Y: 8}, EndPos: advent.Point{X: 3, Y: 8}},
+		{Symbol: true, SymbolC: '*', StartPos: advent.Point{X: 5, Y: 8}, EndPos: advent.Point{X: 5, Y: 8}},
 	}, symbols)
 
 	assert.Equal(t, []*Element{
-		{Value: 467, Symbol: false, StartPos: Position{X: 0, Y: 0}, EndPos: Position{X: 2, Y: 0}},
-		{Value: 114, Symbol: false, StartPos: Position{X: 5, Y: 0}, EndPos: Position{X: 7, Y: 0}},
-		{Value: 35, Symbol: false, StartPos: Position{X: 2, Y: 2}, EndPos: Position{X: 3, Y: 2}},
-		{Value: 633, Symbol: false, StartPos: Position{X: 6, Y: 2}, EndPos: Position{X: 8, Y: 2}},
-		{Value: 617, Symbol: false, StartPos: Position{X: 0, Y: 4}, EndPos: Position{X: 2, Y: 4}},
-		{Value: 58, Symbol: false, StartPos: Position{X: 7, Y: 5}, EndPos: Position{X: 8, Y: 5}},
-		{Value: 592, Symbol: false, StartPos: Position{X: 2, Y: 6}, EndPos: Position{X: 4, Y: 6}},
-		{Value: 755, Symbol: false, StartPos: Position{X: 6, Y: 7}, EndPos: Position{X: 8, Y: 7}},
-		{Value: 664, Symbol: false, StartPos: Position{X: 1, Y: 9}, EndPos: Position{X: 3, Y: 9}},
-		{Value: 598, Symbol: false, StartPos: Position{X: 5, Y: 9}, EndPos: Position{X: 7, Y: 9}},
+		{Value: 467, Symbol: false, StartPos: advent.Point{X: 0, Y: 0}, EndPos: advent.Point{X: 2, Y: 0}},
+		{Value: 114, Symbol: false, StartPos: advent.Point{X: 5, Y: 0}, EndPos: advent.Point{X: 7, Y: 0}},
+		{Value: 35, Symbol: false, StartPos: advent.Point{X: 2, Y: 2}, EndPos: advent.Point{X: 3, Y: 2}},
+		{Value: 633, Symbol: false, StartPos: advent.Point{X: 6, Y: 2}, EndPos: advent.Point{X: 8, Y: 2}},
+		{Value: 617, Symbol: false, StartPos: advent.Point{X: 0, Y: 4}, EndPos: advent.Point{X: 2, Y: 4}},
+		{Value: 58, Symbol: false, StartPos: advent.Point{X: 7, Y: 5}, EndPos: advent.Point{X: 8, Y: 5}},
+		{Value: 592, Symbol: false, StartPos: advent.Point{X: 2, Y: 6}, EndPos: advent.Point{X: 4, Y: 6}},
+		{Value: 755, Symbol: false, StartPos: advent.Point{X: 6, Y: 7}, EndPos: advent.Point{X: 8, Y: 7}},
+		{Value: 664, Symbol: false, StartPos: advent.Point{X: 1, Y: 9}, EndPos: advent.Point{X: 3, Y: 9}},
+		{Value: 598, Symbol: false, StartPos: advent.Point{X: 5, Y: 9}, EndPos: advent.Point{X: 7, Y: 9}},
 	}, parts)
 
-	gears := FindGears(m, symbols)
+	gears := FindGears(m, PuzzleGears)
 
 	assert.Equal(t, []*Gear{
 		{
-			Part1: &Element{Value: 35, Symbol: false, StartPos: Position{X: 2, Y: 2}, EndPos: Position{X: 3, Y: 2}},
-			Part2: &Element{Value: 467, Symbol: false, StartPos: Position{X: 0, Y: 0}, EndPos: Position{X: 2, Y: 0}},
+			Symbol:   '*',
+			Position: advent.Point{X: 3, Y: 1},
+			Parts: []*Element{
+				{Value: 467, Symbol: false, StartPos: advent.Point{X: 0, Y: 0}, EndPos: advent.Point{X: 2, Y: 0}},
+				{Value: 35, Symbol: false, StartPos: advent.Point{X: 2, Y: 2}, EndPos: advent.Point{X: 3, Y: 2}},
+			},
 			Ratio: int64(35 * 467),
 		},
 		{
-			Part1: &Element{Value: 755, Symbol: false, StartPos: Position{X: 6, Y: 7}, EndPos: Position{X: 8, Y: 7}},
-			Part2: &Element{Value: 598, Symbol: false, StartPos: Position{X: 5, Y: 9}, EndPos: Position{X: 7, Y: 9}},
+			Symbol:   '*',
+			Position: advent.Point{X: 5, Y: 8},
+			Parts: []*Element{
+				{Value: 755, Symbol: false, StartPos: advent.Point{X: 6, Y: 7}, EndPos: advent.Point{X: 8, Y: 7}},
+				{Value: 598, Symbol: false, StartPos: advent.Point{X: 5, Y: 9}, EndPos: advent.Point{X: 7, Y: 9}},
+			},
 			Ratio: int64(755 * 598),
 		},
 	}, gears)
 }
+
+func TestGearRules(t *testing.T) {
+	input := io.NopCloser(bytes.NewReader([]byte(`467..114..
+...*......
+..35..633.
+......#...
+617*......
+.....+.58.
+..592.....
+......755.
+...$.*....
+.664.598..`)))
+
+	m, err := InputToSchematic(input, advent.Strict)
+	assert.Nil(t, err)
+
+	type match struct {
+		Symbol   rune
+		Position advent.Point
+		Ratio    int64
+	}
+	matches := func(gears []*Gear) []match {
+		result := []match{}
+		for _, g := range gears {
+			result = append(result, match{g.Symbol, g.Position, g.Ratio})
+		}
+		return result
+	}
+
+	assert.Equal(t, []match{
+		{'*', advent.Point{X: 3, Y: 1}, 467 + 35},
+		{'*', advent.Point{X: 3, Y: 4}, 617},
+		{'*', advent.Point{X: 5, Y: 8}, 755 + 598},
+	}, matches(FindGears(m, GearRule{Symbols: "*", Parts: AtLeast(1), Aggregate: Sum})))
+
+	assert.Equal(t, []match{
+		{'#', advent.Point{X: 6, Y: 3}, 633},
+		{'*', advent.Point{X: 3, Y: 4}, 617},
+		{'+', advent.Point{X: 5, Y: 5}, 592},
+		{'$', advent.Point{X: 3, Y: 8}, 664},
+	}, matches(FindGears(m, GearRule{Parts: Exactly(1), Aggregate: Max})))
+
+	assert.Equal(t, []match{
+		{'#', advent.Point{X: 6, Y: 3}, 633},
+		{'+', advent.Point{X: 5, Y: 5}, 592},
+	}, matches(FindGears(m, GearRule{Symbols: "#+", Parts: AtMost(1), Aggregate: Product})))
+
+	assert.Equal(t, []match{
+		{'*', advent.Point{X: 3, Y: 1}, 467 * 35},
+		{'*', advent.Point{X: 5, Y: 8}, 755 * 598},
+	}, matches(FindGears(m, GearRule{Symbols: "*", Parts: Exactly(2)})))
+
+	assert.Equal(t, int64(0), Product(nil))
+	assert.Equal(t, int64(0), Sum(nil))
+	assert.Equal(t, int64(0), Max(nil))
+	assert.True(t, PartCount{Min: 2, Max: 3}.Match(3))
+	assert.False(t, PartCount{Min: 2, Max: 3}.Match(1))
+}
+
+func TestSchematicQueries(t *testing.T) {
+	input := io.NopCloser(bytes.NewReader([]byte(`467..114..
+...*......
+..35..633.
+......#...
+617*......
+.....+.58.
+..592.....
+......755.
+...$.*....
+.664.598..`)))
+
+	m, err := InputToSchematic(input, advent.Strict)
+	assert.Nil(t, err)
+	assert.Equal(t, 10, m.Width)
+	assert.Equal(t, 10, m.Height)
+
+	values := func(es []*Element) []int64 {
+		result := []int64{}
+		for _, e := range es {
+			result = append(result, e.Value)
+		}
+		return result
+	}
+
+	assert.Equal(t, int64(633), m.NumberAt(advent.Point{X: 8, Y: 2}).Value)
+	assert.Nil(t, m.NumberAt(advent.Point{X: 4, Y: 2}))
+	assert.Nil(t, m.NumberAt(advent.Point{X: 40, Y: 20}))
+
+	assert.Equal(t, []int64{467, 35}, values(m.Touching(Rect{Min: advent.Point{X: 3, Y: 1}, Max: advent.Point{X: 3, Y: 1}})))
+	// multi-row query, numbers only partially inside count as well
+	assert.Equal(t, []int64{467, 114, 35, 633, 617}, values(m.NumbersIn(Rect{Min: advent.Point{X: 2, Y: 0}, Max: advent.Point{X: 6, Y: 4}})))
+	assert.Equal(t, []int64{58, 755}, values(m.NumbersIn(Rect{Min: advent.Point{X: 8, Y: 5}, Max: advent.Point{X: 20, Y: 8}})))
+	assert.Equal(t, []int64{}, values(m.NumbersIn(Rect{Min: advent.Point{X: -5, Y: -5}, Max: advent.Point{X: -1, Y: 20}})))
+
+	n := m.NumberAt(advent.Point{X: 3, Y: 2})
+	assert.Equal(t, []int64{}, values(m.Neighbors(n)))
+	sym := m.SymbolsIn(n.Rect().Grow(1))
+	assert.Equal(t, 1, len(sym))
+	assert.Equal(t, '*', sym[0].SymbolC)
+	assert.Equal(t, []*Element{}, m.SymbolsIn(m.NumberAt(advent.Point{X: 5, Y: 0}).Rect().Grow(1)))
+}
+
+func TestSchematicNeighbors(t *testing.T) {
+	m, err := InputToSchematic(io.NopCloser(bytes.NewReader([]byte(`12....
+..34.5
+7.....`))), advent.Strict)
+	assert.Nil(t, err)
+
+	values := []int64{}
+	for _, e := range m.Neighbors(m.NumberAt(advent.Point{X: 3, Y: 1})) {
+		values = append(values, e.Value)
+	}
+	assert.Equal(t, []int64{12}, values)
+	assert.Equal(t, 0, len(m.Neighbors(m.NumberAt(advent.Point{X: 0, Y: 2}))))
+	assert.Equal(t, 0, len(m.Symbols))
+
+	_, err = InputToSchematic(io.NopCloser(bytes.NewReader([]byte("1.99999999999999999999"))), advent.Strict)
+	assert.ErrorContains(t, err, `line 1, column 3: parsing "99999999999999999999"`)
+
+	_, err = InputToSchematic(io.NopCloser(bytes.NewReader([]byte("\n\n  1.....................\n  ..99999999999999999999"))), advent.Strict)
+	assert.ErrorContains(t, err, `line 4, column 5: parsing "99999999999999999999"`)
+
+	m, err = InputToSchematic(io.NopCloser(bytes.NewReader([]byte("1.99999999999999999999"))), advent.Lenient)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(m.Numbers))
+	assert.Equal(t, int64(1), m.Numbers[0].Value)
+}
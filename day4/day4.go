@@ -1,13 +1,13 @@
-package main
+package day4
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
 	"io"
-	"log"
-	"os"
 	"strconv"
 	"strings"
+
+	"github.com/pedrokiefer/adventofcode-2023/advent"
 )
 
 type Card struct {
@@ -22,42 +22,55 @@ func (c Card) String() string {
 	return fmt.Sprintf("Card %d: %v | %v", c.CardID, c.WinningNumbers, c.MyNumbers)
 }
 
-func InputToCards(input io.ReadCloser) []*Card {
+func InputToCards(input io.ReadCloser, mode advent.ParseMode) ([]*Card, error) {
 	results := []*Card{}
-	s := bufio.NewScanner(input)
 	defer input.Close()
-	for s.Scan() {
-		c, err := ReadCard(s.Text())
+	err := advent.ParseLines(input, mode, func(l string) error {
+		c, err := ReadCard(l)
 		if err != nil {
-			continue
+			return err
 		}
 		results = append(results, c)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return results
+	return results, nil
 }
 
 func ReadCard(s string) (*Card, error) {
 	c := &Card{}
-	parts := strings.Split(s, ":")
-	if len(parts) != 2 {
+	head, body, ok := strings.Cut(s, ":")
+	if !ok {
 		return nil, fmt.Errorf("invalid card string: %s", s)
 	}
 
-	cardIDStr := strings.TrimPrefix(parts[0], "Card ")
+	cardIDStr := strings.TrimPrefix(head, "Card ")
 	cardIDStr = strings.TrimSpace(cardIDStr)
 	cardID, err := strconv.ParseInt(cardIDStr, 10, 64)
 	if err != nil {
-		return nil, fmt.Errorf("invalid card id: %s", cardIDStr)
+		return nil, &advent.ParseError{
+			Column: strings.LastIndex(head, cardIDStr) + 1,
+			Text:   cardIDStr,
+			Err:    errors.New("invalid card id"),
+		}
 	}
 	c.CardID = cardID
 
-	numbers := strings.Split(parts[1], "|")
-	if len(numbers) != 2 {
-		return nil, fmt.Errorf("invalid card numbers: %s", parts[1])
+	winning, mine, ok := strings.Cut(body, "|")
+	if !ok {
+		return nil, fmt.Errorf("invalid card numbers: %s", body)
 	}
 
-	c.WinningNumbers = NumbersToSlice(numbers[0])
-	c.MyNumbers = NumbersToSlice(numbers[1])
+	c.WinningNumbers, err = NumbersToSlice(winning)
+	if err != nil {
+		return nil, advent.Offset(err, len(head)+2)
+	}
+	c.MyNumbers, err = NumbersToSlice(mine)
+	if err != nil {
+		return nil, advent.Offset(err, len(head)+len(winning)+3)
+	}
 
 	c.Points = CheckPoints(c.WinningNumbers, c.MyNumbers)
 	c.MatchingCount = CheckMatching(c.WinningNumbers, c.MyNumbers)
@@ -65,21 +78,20 @@ func ReadCard(s string) (*Card, error) {
 	return c, nil
 }
 
-func NumbersToSlice(numbers string) []int64 {
+func NumbersToSlice(numbers string) ([]int64, error) {
 	results := []int64{}
-	parts := strings.Split(strings.TrimSpace(numbers), " ")
-	for _, n := range parts {
-		n = strings.TrimSpace(n)
-		if n == "" {
-			continue
-		}
-		v, err := strconv.ParseInt(n, 10, 64)
+	for _, f := range advent.Fields(numbers) {
+		v, err := strconv.ParseInt(f.Text, 10, 64)
 		if err != nil {
-			continue
+			return nil, &advent.ParseError{
+				Column: f.Column,
+				Text:   f.Text,
+				Err:    errors.New("invalid number"),
+			}
 		}
 		results = append(results, v)
 	}
-	return results
+	return results, nil
 }
 
 func CheckPoints(winningNumbers []int64, myNumbers []int64) int64 {
@@ -143,25 +155,36 @@ func Ones(lenght int) []int64 {
 	return results
 }
 
-func main() {
-	filename := os.Args[1]
-	f, err := os.Open(filename)
+type Solver struct {
+	cards []*Card
+}
+
+func init() {
+	advent.Register(4, func() advent.Solver { return &Solver{} })
+}
+
+func (s *Solver) Parse(input io.ReadCloser) error {
+	cards, err := InputToCards(input, advent.Strict)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
+	s.cards = cards
+	return nil
+}
 
-	cards := InputToCards(f)
-
+func (s *Solver) Part1() (int, error) {
 	sum := int64(0)
-	for _, c := range cards {
+	for _, c := range s.cards {
 		sum += c.Points
 	}
-	fmt.Printf("Total points: %d\n", sum)
+	return int(sum), nil
+}
 
-	matching := CountMatching(cards)
-	sum2 := int64(0)
+func (s *Solver) Part2() (int, error) {
+	matching := CountMatching(s.cards)
+	sum := int64(0)
 	for _, v := range matching {
-		sum2 += v
+		sum += v
 	}
-	fmt.Printf("Total matching: %d\n", sum2)
+	return int(sum), nil
 }
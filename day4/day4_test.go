@@ -1,10 +1,11 @@
-package main
+package day4
 
 import (
 	"bytes"
 	"io"
 	"testing"
 
+	"github.com/pedrokiefer/adventofcode-2023/advent"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -16,7 +17,8 @@ Card 4: 41 92 73 84 69 | 59 84 76 51 58  5 54 83
 Card 5: 87 83 26 28 32 | 88 30 70 12 93 22 82 36
 Card 6: 31 18 13 56 72 | 74 77 10 23 35 67 36 11`)))
 
-	cards := InputToCards(input)
+	cards, err := InputToCards(input, advent.Strict)
+	assert.Nil(t, err)
 
 	assert.Equal(t, []*Card{
 		{
@@ -72,3 +74,26 @@ Card 6: 31 18 13 56 72 | 74 77 10 23 35 67 36 11`)))
 	matching := CountMatching(cards)
 	assert.Equal(t, []int64{1, 2, 4, 8, 14, 1}, matching)
 }
+
+func TestInputToCardsMode(t *testing.T) {
+	input := []byte(`Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53
+Card 2: 13 32 20 16 61 | 61 30 68 82 17 32 24 19
+Card 3:  1 21 53 59 44 | 69 82 63 72 I6 21 14  1
+Card 4 41 92 73 84 69 | 59 84 76 51 58  5 54 83
+Card x: 87 83 26 28 32 | 88 30 70 12 93 22 82 36
+Card 6: 31 18 13 56 72 | 74 77 10 23 35 67 36 11`)
+
+	cards, err := InputToCards(io.NopCloser(bytes.NewReader(input)), advent.Lenient)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(cards))
+	assert.Equal(t, int64(6), cards[2].CardID)
+
+	_, err = InputToCards(io.NopCloser(bytes.NewReader(input)), advent.Strict)
+	assert.EqualError(t, err, `line 3, column 38: parsing "I6": invalid number`)
+
+	_, err = ReadCard("Card x: 87 83 26 28 32 | 88 30 70 12 93 22 82 36")
+	assert.EqualError(t, err, `column 6: parsing "x": invalid card id`)
+
+	_, err = ReadCard("Card 1: 87 83 26 28 3z | 88")
+	assert.EqualError(t, err, `column 21: parsing "3z": invalid number`)
+}
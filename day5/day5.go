@@ -0,0 +1,720 @@
+package day5
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pedrokiefer/adventofcode-2023/advent"
+	"github.com/pedrokiefer/adventofcode-2023/advent/search"
+)
+
+var (
+	Seeds    = "seeds"
+	Seed     = "seed"
+	Location = "location"
+)
+
+type Interval struct {
+	Start  int64
+	Length int64
+}
+
+type Range struct {
+	DestinationStart int64
+	SourceStart      int64
+	Length           int64
+}
+
+// CategoryMap converts values from the Source category to the Destination
+// one, as described by an "X-to-Y map" section. Ranges keeps them as read,
+// Table is the same mapping ready for lookups.
+type CategoryMap struct {
+	Source      string
+	Destination string
+	Ranges      []Range
+	Table       Table
+}
+
+// Table is a range table sorted by source that covers every value from zero
+// up, the gaps between ranges being filled with identity ranges, so values
+// are mapped with a binary search.
+type Table []Range
+
+func (r Range) clip(start, end int64) Range {
+	s := max(r.SourceStart, start)
+	e := min(r.SourceStart+r.Length, end)
+	return Range{
+		DestinationStart: r.DestinationStart + (s - r.SourceStart),
+		SourceStart:      s,
+		Length:           e - s,
+	}
+}
+
+func NewTable(rs []Range) Table {
+	t := Table{}
+	for _, r := range rs {
+		// like Map, the first range covering a value wins
+		pieces := []Range{}
+		if c := r.clip(0, math.MaxInt64); c.Length > 0 {
+			pieces = append(pieces, c)
+		}
+		for _, o := range t {
+			rest := []Range{}
+			for _, p := range pieces {
+				for _, c := range []Range{
+					p.clip(p.SourceStart, o.SourceStart),
+					p.clip(o.SourceStart+o.Length, p.SourceStart+p.Length),
+				} {
+					if c.Length > 0 {
+						rest = append(rest, c)
+					}
+				}
+			}
+			pieces = rest
+		}
+		t = append(t, pieces...)
+	}
+	sort.Slice(t, func(i, j int) bool {
+		return t[i].SourceStart < t[j].SourceStart
+	})
+	return t.fill()
+}
+
+func (t Table) fill() Table {
+	filled := Table{}
+	cur := int64(0)
+	for _, r := range t {
+		if r.SourceStart > cur {
+			filled = append(filled, Range{DestinationStart: cur, SourceStart: cur, Length: r.SourceStart - cur})
+		}
+		filled = append(filled, r)
+		cur = r.SourceStart + r.Length
+	}
+	if cur < math.MaxInt64 {
+		filled = append(filled, Range{DestinationStart: cur, SourceStart: cur, Length: math.MaxInt64 - cur})
+	}
+	return filled
+}
+
+func (t Table) Map(v int64) int64 {
+	i := sort.Search(len(t), func(i int) bool {
+		return t[i].SourceStart > v
+	}) - 1
+	if i < 0 || v >= t[i].SourceStart+t[i].Length {
+		return v
+	}
+	return t[i].DestinationStart + (v - t[i].SourceStart)
+}
+
+// MapInterval maps i through the table, splitting it wherever it crosses a
+// range boundary, like PartitionInterval does with a binary search for the
+// first range it overlaps. The result is sorted by start.
+func (t Table) MapInterval(i Interval) []Interval {
+	results := []Interval{}
+	lo, hi := i.Start, i.Start+i.Length
+	j := sort.Search(len(t), func(j int) bool {
+		return t[j].SourceStart+t[j].Length > lo
+	})
+	for ; lo < hi && j < len(t) && t[j].SourceStart < hi; j++ {
+		if t[j].SourceStart > lo {
+			results = append(results, Interval{Start: lo, Length: t[j].SourceStart - lo})
+		}
+		c := t[j].clip(lo, hi)
+		results = append(results, Interval{Start: c.DestinationStart, Length: c.Length})
+		lo = c.SourceStart + c.Length
+	}
+	if lo < hi {
+		results = append(results, Interval{Start: lo, Length: hi - lo})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Start < results[j].Start
+	})
+	return results
+}
+
+// Compose returns the table mapping every value v to next.Map(t.Map(v)).
+func (t Table) Compose(next Table) Table {
+	result := Table{}
+	for _, r := range t {
+		lo := r.DestinationStart
+		hi := r.DestinationStart + r.Length
+		j := sort.Search(len(next), func(j int) bool {
+			return next[j].SourceStart+next[j].Length > lo
+		})
+		for ; j < len(next) && next[j].SourceStart < hi; j++ {
+			c := next[j].clip(lo, hi)
+			result = append(result, Range{
+				DestinationStart: c.DestinationStart,
+				SourceStart:      r.SourceStart + (c.SourceStart - lo),
+				Length:           c.Length,
+			})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].SourceStart < result[j].SourceStart
+	})
+
+	merged := Table{}
+	for _, r := range result {
+		if n := len(merged); n > 0 {
+			last := &merged[n-1]
+			if last.SourceStart+last.Length == r.SourceStart && last.DestinationStart+last.Length == r.DestinationStart {
+				last.Length += r.Length
+				continue
+			}
+		}
+		merged = append(merged, r)
+	}
+	return merged.fill()
+}
+
+// Almanac holds the seeds and every category map, keyed by their source
+// category.
+type Almanac struct {
+	Seeds []int64
+	Maps  map[string][]CategoryMap
+}
+
+func NewAlmanac() *Almanac {
+	return &Almanac{
+		Maps: map[string][]CategoryMap{},
+	}
+}
+
+// AddMap adds the ranges of the map named "X-to-Y", which must not already be
+// known.
+func (a *Almanac) AddMap(name string, r []Range) error {
+	parts := strings.Split(name, "-to-")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("invalid map name: %s", name)
+	}
+	for _, m := range a.Maps[parts[0]] {
+		if m.Destination == parts[1] {
+			return fmt.Errorf("duplicate map: %s", name)
+		}
+	}
+	a.Maps[parts[0]] = append(a.Maps[parts[0]], CategoryMap{
+		Source:      parts[0],
+		Destination: parts[1],
+		Ranges:      r,
+		Table:       NewTable(r),
+	})
+	return nil
+}
+
+func (a *Almanac) categoryMap(from, to string) (CategoryMap, bool) {
+	for _, m := range a.Maps[from] {
+		if m.Destination == to {
+			return m, true
+		}
+	}
+	return CategoryMap{}, false
+}
+
+// GetMap returns the ranges converting from one category directly to another.
+func (a *Almanac) GetMap(from, to string) ([]Range, bool) {
+	m, ok := a.categoryMap(from, to)
+	return m.Ranges, ok
+}
+
+// Path resolves the shortest chain of maps converting from one category to
+// another.
+func (a *Almanac) Path(from, to string) ([]CategoryMap, error) {
+	r, ok := search.BFS([]string{from}, func(c string) []string {
+		next := []string{}
+		for _, m := range a.Maps[c] {
+			next = append(next, m.Destination)
+		}
+		return next
+	}, func(c string) bool {
+		return c == to
+	})
+	if !ok {
+		return nil, fmt.Errorf("no chain of maps from %s to %s", from, to)
+	}
+
+	path := []CategoryMap{}
+	for i := 1; i < len(r.Path); i++ {
+		m, _ := a.categoryMap(r.Path[i-1], r.Path[i])
+		path = append(path, m)
+	}
+	return path, nil
+}
+
+// InputToAlmanac reads the seeds and the category maps. In Lenient mode
+// the seeds or range lines with bad numbers are skipped, as are the range
+// lines outside of a map and the maps with an invalid or duplicated name.
+func InputToAlmanac(input io.ReadCloser, mode advent.ParseMode) (*Almanac, error) {
+	a := NewAlmanac()
+	s := bufio.NewScanner(input)
+	defer input.Close()
+	rangeName := ""
+	rangeLine := 0
+	rangeHeader := ""
+	curRange := []Range{}
+	flush := func() error {
+		name, ranges := rangeName, curRange
+		curRange = []Range{}
+		rangeName = ""
+		if name == "" {
+			return nil
+		}
+		err := a.AddMap(name, ranges)
+		if err != nil && mode == advent.Strict {
+			return advent.AtLine(err, rangeLine, rangeHeader)
+		}
+		return nil
+	}
+	line := 0
+	for s.Scan() {
+		line++
+		l := s.Text()
+		if strings.TrimSpace(l) == "" {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if head, body, ok := strings.Cut(l, ":"); ok {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			head = strings.TrimSpace(head)
+
+			if head == Seeds {
+				seeds, err := NumbersToSlice(body)
+				if err != nil {
+					if mode == advent.Strict {
+						return nil, advent.AtLine(advent.Offset(err, len(head)+2), line, l)
+					}
+					continue
+				}
+				a.Seeds = seeds
+				continue
+			}
+
+			rangeName = strings.TrimSuffix(head, " map")
+			rangeLine = line
+			rangeHeader = l
+		} else {
+			if rangeName == "" {
+				if mode == advent.Strict {
+					return nil, advent.AtLine(errors.New("range outside of a map"), line, l)
+				}
+				continue
+			}
+			r, err := ReadRange(l)
+			if err != nil {
+				if mode == advent.Strict {
+					return nil, advent.AtLine(err, line, l)
+				}
+				continue
+			}
+			curRange = append(curRange, r)
+		}
+
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// ReadRange reads a "destination source length" line of a category map.
+func ReadRange(l string) (Range, error) {
+	v, err := NumbersToSlice(l)
+	if err != nil {
+		return Range{}, err
+	}
+	if len(v) != 3 {
+		return Range{}, fmt.Errorf("expected 3 numbers, got %d", len(v))
+	}
+	return Range{
+		DestinationStart: v[0],
+		SourceStart:      v[1],
+		Length:           v[2],
+	}, nil
+}
+
+func NumbersToSlice(numbers string) ([]int64, error) {
+	results := []int64{}
+	for _, f := range advent.Fields(numbers) {
+		v, err := strconv.ParseInt(f.Text, 10, 64)
+		if err != nil {
+			return nil, &advent.ParseError{
+				Column: f.Column,
+				Text:   f.Text,
+				Err:    errors.New("invalid number"),
+			}
+		}
+		results = append(results, v)
+	}
+	return results, nil
+}
+
+func (a Almanac) Map(v int64, rs []Range) int64 {
+	for _, r := range rs {
+		if v >= r.SourceStart && v < r.SourceStart+r.Length {
+			return r.DestinationStart + (v - r.SourceStart)
+		}
+	}
+	return v
+}
+
+// Convert maps a value of one category into another, following the chain
+// of maps between them.
+func (a Almanac) Convert(v int64, from, to string) (int64, error) {
+	path, err := a.Path(from, to)
+	if err != nil {
+		return 0, err
+	}
+	for _, m := range path {
+		v = m.Table.Map(v)
+	}
+	return v, nil
+}
+
+// Compose merges the tables of every map between two categories into a
+// single one.
+func (a Almanac) Compose(from, to string) (Table, error) {
+	path, err := a.Path(from, to)
+	if err != nil {
+		return nil, err
+	}
+	t := NewTable(nil)
+	for _, m := range path {
+		t = t.Compose(m.Table)
+	}
+	return t, nil
+}
+
+func (a Almanac) Location(v int64) (int64, error) {
+	return a.Convert(v, Seed, Location)
+}
+
+func FindLowestLocation(a *Almanac) (int64, error) {
+	t, err := a.Compose(Seed, Location)
+	if err != nil {
+		return 0, err
+	}
+	lowest := int64(-1)
+	for _, s := range a.Seeds {
+		l := t.Map(s)
+		if lowest == -1 || l < lowest {
+			lowest = l
+		}
+	}
+	return lowest, nil
+}
+
+// SeedsToIntervals reads the seeds as part two does, in start and length
+// pairs.
+func SeedsToIntervals(seeds []int64) ([]Interval, error) {
+	if len(seeds)%2 != 0 {
+		return nil, fmt.Errorf("seeds are not in pairs: got %d numbers", len(seeds))
+	}
+	results := []Interval{}
+	for i := 0; i < len(seeds); i += 2 {
+		s, r := seeds[i], seeds[i+1]
+		results = append(results, Interval{
+			Start:  s,
+			Length: r,
+		})
+		advent.Debugf("Start Seed: %d range %d", s, r)
+	}
+	return results, nil
+}
+
+// PartitionInterval maps i through the range table, splitting it wherever
+// it crosses a range boundary. Pieces not covered by any range keep their
+// values. The result is sorted by start.
+func PartitionInterval(i Interval, rs []Range) []Interval {
+	results := []Interval{}
+	pending := []Interval{i}
+	for _, r := range rs {
+		rMin := r.SourceStart
+		rMax := r.SourceStart + r.Length
+		unmapped := []Interval{}
+		for _, p := range pending {
+			pMin := p.Start
+			pMax := p.Start + p.Length
+			if pMax <= rMin || pMin >= rMax {
+				unmapped = append(unmapped, p)
+				continue
+			}
+
+			maxMin := max(pMin, rMin)
+			minMax := min(pMax, rMax)
+			results = append(results, Interval{
+				Start:  r.DestinationStart + (maxMin - r.SourceStart),
+				Length: minMax - maxMin,
+			})
+
+			if pMin < maxMin {
+				unmapped = append(unmapped, Interval{Start: pMin, Length: maxMin - pMin})
+			}
+			if minMax < pMax {
+				unmapped = append(unmapped, Interval{Start: minMax, Length: pMax - minMax})
+			}
+		}
+		pending = unmapped
+	}
+	results = append(results, pending...)
+
+	nonEmpty := []Interval{}
+	for _, r := range results {
+		if r.Length > 0 {
+			nonEmpty = append(nonEmpty, r)
+		}
+	}
+	sort.Slice(nonEmpty, func(i, j int) bool {
+		return nonEmpty[i].Start < nonEmpty[j].Start
+	})
+	return nonEmpty
+}
+
+func MapIntervals(intervals []Interval, rs []Range) []Interval {
+	results := []Interval{}
+	for _, i := range intervals {
+		ni := PartitionInterval(i, rs)
+		results = append(results, ni...)
+	}
+	return results
+}
+
+// ConvertIntervals maps intervals of one category into another, following
+// the chain of maps between them.
+func (a Almanac) ConvertIntervals(intervals []Interval, from, to string) ([]Interval, error) {
+	path, err := a.Path(from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range path {
+		next := []Interval{}
+		for _, i := range intervals {
+			next = append(next, m.Table.MapInterval(i)...)
+		}
+		intervals = next
+		advent.Debugf("New intervals: %+v", intervals)
+	}
+	return intervals, nil
+}
+
+func FindLowestLocation2(a *Almanac) (int64, error) {
+	seeds, err := SeedsToIntervals(a.Seeds)
+	if err != nil {
+		return 0, err
+	}
+	intervals, err := a.ConvertIntervals(seeds, Seed, Location)
+	if err != nil {
+		return 0, err
+	}
+
+	lowest := int64(-1)
+	for _, i := range intervals {
+		if lowest == -1 || i.Start < lowest {
+			lowest = i.Start
+		}
+	}
+	return lowest, nil
+}
+
+// Unmap returns every value that Map turns into v: one for each range whose
+// destination covers v, plus v itself when no range source covers it.
+func (a Almanac) Unmap(v int64, rs []Range) []int64 {
+	results := []int64{}
+	identity := true
+	for _, r := range rs {
+		if v >= r.DestinationStart && v < r.DestinationStart+r.Length {
+			results = append(results, r.SourceStart+(v-r.DestinationStart))
+		}
+		if v >= r.SourceStart && v < r.SourceStart+r.Length {
+			identity = false
+		}
+	}
+	if identity {
+		results = append(results, v)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i] < results[j]
+	})
+	return results
+}
+
+// UnmapInterval returns the intervals that PartitionInterval maps into i,
+// sorted by start.
+func UnmapInterval(i Interval, rs []Range) []Interval {
+	results := []Interval{}
+	iMin := i.Start
+	iMax := i.Start + i.Length
+	identity := []Interval{i}
+	for _, r := range rs {
+		dMin := r.DestinationStart
+		dMax := r.DestinationStart + r.Length
+		if iMin < dMax && iMax > dMin {
+			maxMin := max(iMin, dMin)
+			minMax := min(iMax, dMax)
+			results = append(results, Interval{
+				Start:  r.SourceStart + (maxMin - r.DestinationStart),
+				Length: minMax - maxMin,
+			})
+		}
+
+		// values inside a range source never map to themselves
+		sMin := r.SourceStart
+		sMax := r.SourceStart + r.Length
+		rest := []Interval{}
+		for _, p := range identity {
+			pMin := p.Start
+			pMax := p.Start + p.Length
+			if pMax <= sMin || pMin >= sMax {
+				rest = append(rest, p)
+				continue
+			}
+			if pMin < sMin {
+				rest = append(rest, Interval{Start: pMin, Length: sMin - pMin})
+			}
+			if sMax < pMax {
+				rest = append(rest, Interval{Start: sMax, Length: pMax - sMax})
+			}
+		}
+		identity = rest
+	}
+	results = append(results, identity...)
+
+	nonEmpty := []Interval{}
+	for _, r := range results {
+		if r.Length > 0 {
+			nonEmpty = append(nonEmpty, r)
+		}
+	}
+	sort.Slice(nonEmpty, func(i, j int) bool {
+		return nonEmpty[i].Start < nonEmpty[j].Start
+	})
+	return nonEmpty
+}
+
+// Reverse walks the chain of maps backwards, returning every value of the
+// to category that Convert turns into v of the from category.
+func (a Almanac) Reverse(v int64, from, to string) ([]int64, error) {
+	path, err := a.Path(to, from)
+	if err != nil {
+		return nil, err
+	}
+	values := []int64{v}
+	for i := len(path) - 1; i >= 0; i-- {
+		seen := map[int64]bool{}
+		next := []int64{}
+		for _, v := range values {
+			for _, u := range a.Unmap(v, path[i].Ranges) {
+				if !seen[u] {
+					seen[u] = true
+					next = append(next, u)
+				}
+			}
+		}
+		values = next
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i] < values[j]
+	})
+	return values, nil
+}
+
+// ReverseIntervals is Reverse for intervals.
+func (a Almanac) ReverseIntervals(intervals []Interval, from, to string) ([]Interval, error) {
+	path, err := a.Path(to, from)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		next := []Interval{}
+		for _, in := range intervals {
+			next = append(next, UnmapInterval(in, path[i].Ranges)...)
+		}
+		intervals = next
+	}
+	return intervals, nil
+}
+
+// SeedsForLocation returns every seed number that ends up in location v.
+func (a Almanac) SeedsForLocation(v int64) ([]int64, error) {
+	return a.Reverse(v, Location, Seed)
+}
+
+func overlaps(a, b []Interval) bool {
+	for _, i := range a {
+		for _, j := range b {
+			if i.Start < j.Start+j.Length && j.Start < i.Start+i.Length {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ValidateLowestLocation2 checks a part two answer by walking the chain
+// backwards: some seed range must reach location l and none may reach a
+// lower location.
+func ValidateLowestLocation2(a *Almanac, l int64) error {
+	seeds, err := SeedsToIntervals(a.Seeds)
+	if err != nil {
+		return err
+	}
+
+	at, err := a.ReverseIntervals([]Interval{{Start: l, Length: 1}}, Location, Seed)
+	if err != nil {
+		return err
+	}
+	if !overlaps(at, seeds) {
+		return fmt.Errorf("no seed reaches location %d", l)
+	}
+
+	if l > 0 {
+		below, err := a.ReverseIntervals([]Interval{{Start: 0, Length: l}}, Location, Seed)
+		if err != nil {
+			return err
+		}
+		if overlaps(below, seeds) {
+			return fmt.Errorf("a seed reaches a location lower than %d", l)
+		}
+	}
+	return nil
+}
+
+type Solver struct {
+	almanac *Almanac
+}
+
+func init() {
+	advent.Register(5, func() advent.Solver { return &Solver{} })
+}
+
+func (s *Solver) Parse(input io.ReadCloser) error {
+	a, err := InputToAlmanac(input, advent.Strict)
+	if err != nil {
+		return err
+	}
+	s.almanac = a
+	return nil
+}
+
+func (s *Solver) Part1() (int, error) {
+	l, err := FindLowestLocation(s.almanac)
+	return int(l), err
+}
+
+func (s *Solver) Part2() (int, error) {
+	l, err := FindLowestLocation2(s.almanac)
+	return int(l), err
+}
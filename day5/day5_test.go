@@ -1,10 +1,13 @@
-package main
+package day5
 
 import (
 	"bytes"
 	"io"
+	"math"
+	"os"
 	"testing"
 
+	"github.com/pedrokiefer/adventofcode-2023/advent"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,24 +46,158 @@ humidity-to-location map:
 60 56 37
 56 93 4`)))
 
-	a := InputToAlmanac(input)
+	a, err := InputToAlmanac(input, advent.Strict)
+	assert.Nil(t, err)
 
+	seedToSoil, ok := a.GetMap(Seed, "soil")
+	assert.True(t, ok)
 	assert.Equal(t, []int64{79, 14, 55, 13}, a.Seeds)
 	assert.Equal(t, []Range{
 		{DestinationStart: 50, SourceStart: 98, Length: 2},
 		{DestinationStart: 52, SourceStart: 50, Length: 48},
-	}, a.SeedToSoil)
+	}, seedToSoil)
 
-	v := a.Map(int64(79), a.SeedToSoil)
+	v := a.Map(int64(79), seedToSoil)
 	assert.Equal(t, int64(81), v)
 
-	v = a.Location(int64(79))
+	v, err = a.Location(int64(79))
+	assert.Nil(t, err)
 	assert.Equal(t, int64(82), v)
 
-	l := FindLowestLocation2(a)
+	l, err := FindLowestLocation(a)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(35), l)
+
+	l, err = FindLowestLocation2(a)
+	assert.Nil(t, err)
 	assert.Equal(t, int64(46), l)
 }
 
+func TestAlmanacChain(t *testing.T) {
+	input := io.NopCloser(bytes.NewReader([]byte(`seeds: 1 5
+
+soil-to-ore map:
+100 0 10
+
+seed-to-soil map:
+10 0 5
+
+ore-to-location map:
+0 100 200
+
+seed-to-ore map:
+50 0 10
+`)))
+
+	a, err := InputToAlmanac(input, advent.Strict)
+	assert.Nil(t, err)
+
+	path, err := a.Path(Seed, Location)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(path))
+	assert.Equal(t, "seed", path[0].Source)
+	assert.Equal(t, "ore", path[0].Destination)
+	assert.Equal(t, "location", path[1].Destination)
+
+	v, err := a.Convert(1, Seed, "soil")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(11), v)
+
+	v, err = a.Convert(1, "soil", Location)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), v)
+
+	v, err = a.Location(1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(51), v)
+
+	_, err = a.Convert(1, Location, Seed)
+	assert.EqualError(t, err, "no chain of maps from location to seed")
+}
+
+func TestAlmanacBrokenChain(t *testing.T) {
+	input := io.NopCloser(bytes.NewReader([]byte(`seeds: 79 14
+
+seed-to-soil map:
+50 98 2
+
+fertilizer-to-location map:
+0 0 10
+`)))
+
+	a, err := InputToAlmanac(input, advent.Strict)
+	assert.Nil(t, err)
+
+	_, err = FindLowestLocation(a)
+	assert.EqualError(t, err, "no chain of maps from seed to location")
+	_, err = FindLowestLocation2(a)
+	assert.EqualError(t, err, "no chain of maps from seed to location")
+}
+
+func TestAlmanacInvalidMaps(t *testing.T) {
+	_, err := InputToAlmanac(io.NopCloser(bytes.NewReader([]byte(`seeds: 1
+seed-soil map:
+1 2 3`))), advent.Strict)
+	assert.EqualError(t, err, `line 2: parsing "seed-soil map:": invalid map name: seed-soil`)
+
+	duplicate := []byte(`seeds: 1
+seed-to-soil map:
+1 2 3
+seed-to-soil map:
+4 5 6`)
+	_, err = InputToAlmanac(io.NopCloser(bytes.NewReader(duplicate)), advent.Strict)
+	assert.EqualError(t, err, `line 4: parsing "seed-to-soil map:": duplicate map: seed-to-soil`)
+
+	a, err := InputToAlmanac(io.NopCloser(bytes.NewReader(duplicate)), advent.Lenient)
+	assert.Nil(t, err)
+	rs, ok := a.GetMap(Seed, "soil")
+	assert.True(t, ok)
+	assert.Equal(t, []Range{{DestinationStart: 1, SourceStart: 2, Length: 3}}, rs)
+}
+
+func TestAlmanacRangeOutsideMap(t *testing.T) {
+	input := []byte("seeds: 79\n1 2 3\nseed-to-soil map:\n50 98 2")
+
+	_, err := InputToAlmanac(io.NopCloser(bytes.NewReader(input)), advent.Strict)
+	assert.EqualError(t, err, `line 2: parsing "1 2 3": range outside of a map`)
+
+	a, err := InputToAlmanac(io.NopCloser(bytes.NewReader(input)), advent.Lenient)
+	assert.Nil(t, err)
+	rs, ok := a.GetMap(Seed, "soil")
+	assert.True(t, ok)
+	assert.Equal(t, []Range{{DestinationStart: 50, SourceStart: 98, Length: 2}}, rs)
+
+	_, err = InputToAlmanac(io.NopCloser(bytes.NewReader([]byte("seed-to-soil map:\n50 98 2\n\n1 2 3"))), advent.Strict)
+	assert.EqualError(t, err, `line 4: parsing "1 2 3": range outside of a map`)
+}
+
+func TestAlmanacMode(t *testing.T) {
+	input := []byte(`seeds: 79 14
+
+seed-to-soil map:
+50 98 2
+52 5O 48
+1 2
+
+soil-to-location map:
+0 15 37`)
+
+	a, err := InputToAlmanac(io.NopCloser(bytes.NewReader(input)), advent.Lenient)
+	assert.Nil(t, err)
+	rs, ok := a.GetMap(Seed, "soil")
+	assert.True(t, ok)
+	assert.Equal(t, []Range{{DestinationStart: 50, SourceStart: 98, Length: 2}}, rs)
+
+	_, err = InputToAlmanac(io.NopCloser(bytes.NewReader(input)), advent.Strict)
+	assert.EqualError(t, err, `line 5, column 4: parsing "5O": invalid number`)
+
+	_, err = InputToAlmanac(io.NopCloser(bytes.NewReader([]byte("seeds: 79 1_4"))), advent.Strict)
+	assert.EqualError(t, err, `line 1, column 11: parsing "1_4": invalid number`)
+
+	_, err = ReadRange("1 2")
+	assert.EqualError(t, err, "expected 3 numbers, got 2")
+}
+
 func TestPartitionInterval(t *testing.T) {
 	ranges := []Range{
 		{DestinationStart: 49, SourceStart: 53, Length: 8},
@@ -90,6 +227,304 @@ func TestPartitionInterval2(t *testing.T) {
 		{Start: 40, Length: 10},
 		{Start: 50, Length: 10},
 		{Start: 60, Length: 10},
-		{Start: 70, Length: 10},
+		{Start: 70, Length: 20},
 	}, partitioned)
 }
+
+func TestPartitionIntervalPartialOverlap(t *testing.T) {
+	ranges := []Range{
+		{DestinationStart: 50, SourceStart: 98, Length: 2},
+		{DestinationStart: 52, SourceStart: 50, Length: 48},
+	}
+	partitioned := PartitionInterval(Interval{Start: 90, Length: 15}, ranges)
+	assert.Equal(t, []Interval{
+		{Start: 50, Length: 2},
+		{Start: 92, Length: 8},
+		{Start: 100, Length: 5},
+	}, partitioned)
+}
+
+func TestMapIntervalsKeepsAllValues(t *testing.T) {
+	ranges := []Range{
+		{DestinationStart: 0, SourceStart: 15, Length: 37},
+		{DestinationStart: 37, SourceStart: 52, Length: 2},
+		{DestinationStart: 39, SourceStart: 0, Length: 15},
+	}
+	intervals := []Interval{{Start: 0, Length: 60}, {Start: 100, Length: 3}}
+	mapped := MapIntervals(intervals, ranges)
+
+	total := int64(0)
+	for _, i := range mapped {
+		total += i.Length
+	}
+	assert.Equal(t, int64(63), total)
+
+	a := Almanac{}
+	for v := int64(0); v < 60; v++ {
+		m := a.Map(v, ranges)
+		found := false
+		for _, i := range mapped {
+			if m >= i.Start && m < i.Start+i.Length {
+				found = true
+			}
+		}
+		assert.True(t, found, "value %d mapped to %d", v, m)
+	}
+}
+
+func TestTableMapInterval(t *testing.T) {
+	ranges := []Range{
+		{DestinationStart: 0, SourceStart: 15, Length: 37},
+		{DestinationStart: 37, SourceStart: 52, Length: 2},
+		{DestinationStart: 39, SourceStart: 0, Length: 15},
+	}
+	table := NewTable(ranges)
+	for _, i := range []Interval{
+		{Start: 0, Length: 60},
+		{Start: 10, Length: 10},
+		{Start: 53, Length: 1},
+		{Start: 100, Length: 3},
+		{Start: 5, Length: 0},
+	} {
+		assert.Equal(t, PartitionInterval(i, ranges), table.MapInterval(i), "interval %+v", i)
+	}
+
+	assert.Equal(t, []Interval{{Start: 3, Length: 4}}, Table{}.MapInterval(Interval{Start: 3, Length: 4}))
+}
+
+var example = `seeds: 79 14 55 13
+
+seed-to-soil map:
+50 98 2
+52 50 48
+
+soil-to-fertilizer map:
+0 15 37
+37 52 2
+39 0 15
+
+fertilizer-to-water map:
+49 53 8
+0 11 42
+42 0 7
+57 7 4
+
+water-to-light map:
+88 18 7
+18 25 70
+
+light-to-temperature map:
+45 77 23
+81 45 19
+68 64 13
+
+temperature-to-humidity map:
+0 69 1
+1 0 69
+
+humidity-to-location map:
+60 56 37
+56 93 4`
+
+func TestUnmap(t *testing.T) {
+	a := Almanac{}
+	ranges := []Range{
+		{DestinationStart: 50, SourceStart: 98, Length: 2},
+		{DestinationStart: 52, SourceStart: 50, Length: 48},
+	}
+	assert.Equal(t, []int64{79}, a.Unmap(81, ranges))
+	assert.Equal(t, []int64{98}, a.Unmap(50, ranges))
+	assert.Equal(t, []int64{10}, a.Unmap(10, ranges))
+	// 99 comes from 97 only, as 99 itself is mapped to 51
+	assert.Equal(t, []int64{97}, a.Unmap(99, ranges))
+	assert.Equal(t, []int64{}, a.Unmap(52, []Range{{DestinationStart: 0, SourceStart: 50, Length: 10}}))
+	assert.Equal(t, []int64{5, 105}, a.Unmap(105, []Range{{DestinationStart: 100, SourceStart: 0, Length: 10}}))
+}
+
+func TestUnmapInterval(t *testing.T) {
+	ranges := []Range{
+		{DestinationStart: 50, SourceStart: 98, Length: 2},
+		{DestinationStart: 52, SourceStart: 50, Length: 48},
+	}
+	assert.Equal(t, []Interval{
+		{Start: 40, Length: 10},
+	}, UnmapInterval(Interval{Start: 40, Length: 10}, ranges))
+	assert.Equal(t, []Interval{
+		{Start: 45, Length: 5},
+		{Start: 50, Length: 5},
+		{Start: 98, Length: 2},
+	}, UnmapInterval(Interval{Start: 45, Length: 12}, ranges))
+
+	a := Almanac{}
+	for v := int64(0); v < 110; v++ {
+		for _, i := range UnmapInterval(Interval{Start: v, Length: 1}, ranges) {
+			for u := i.Start; u < i.Start+i.Length; u++ {
+				assert.Equal(t, v, a.Map(u, ranges))
+			}
+		}
+		assert.Equal(t, len(a.Unmap(v, ranges)), int(sumLengths(UnmapInterval(Interval{Start: v, Length: 1}, ranges))))
+	}
+}
+
+func sumLengths(intervals []Interval) int64 {
+	total := int64(0)
+	for _, i := range intervals {
+		total += i.Length
+	}
+	return total
+}
+
+func TestReverse(t *testing.T) {
+	a, err := InputToAlmanac(io.NopCloser(bytes.NewReader([]byte(example))), advent.Strict)
+	assert.Nil(t, err)
+
+	seeds, err := a.SeedsForLocation(82)
+	assert.Nil(t, err)
+	assert.Contains(t, seeds, int64(79))
+	for _, s := range seeds {
+		l, err := a.Location(s)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(82), l)
+	}
+
+	soil, err := a.Reverse(81, "soil", Seed)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{79}, soil)
+
+	intervals, err := a.ReverseIntervals([]Interval{{Start: 46, Length: 1}}, Location, Seed)
+	assert.Nil(t, err)
+	assert.True(t, overlaps(intervals, []Interval{{Start: 82, Length: 1}}))
+
+	_, err = a.Reverse(1, Seed, Location)
+	assert.EqualError(t, err, "no chain of maps from location to seed")
+}
+
+func TestValidateLowestLocation2(t *testing.T) {
+	a, err := InputToAlmanac(io.NopCloser(bytes.NewReader([]byte(example))), advent.Strict)
+	assert.Nil(t, err)
+
+	assert.Nil(t, ValidateLowestLocation2(a, 46))
+	assert.EqualError(t, ValidateLowestLocation2(a, 47), "a seed reaches a location lower than 47")
+	assert.EqualError(t, ValidateLowestLocation2(a, 45), "no seed reaches location 45")
+}
+
+func TestSeedsToIntervals(t *testing.T) {
+	is, err := SeedsToIntervals([]int64{79, 14, 55, 13})
+	assert.Nil(t, err)
+	assert.Equal(t, []Interval{{Start: 79, Length: 14}, {Start: 55, Length: 13}}, is)
+
+	_, err = SeedsToIntervals([]int64{79, 14, 55})
+	assert.EqualError(t, err, "seeds are not in pairs: got 3 numbers")
+
+	a, err := InputToAlmanac(io.NopCloser(bytes.NewReader([]byte("seeds: 79 14 55"))), advent.Strict)
+	assert.Nil(t, err)
+	_, err = FindLowestLocation2(a)
+	assert.EqualError(t, err, "seeds are not in pairs: got 3 numbers")
+}
+
+func TestNewTable(t *testing.T) {
+	ranges := []Range{
+		{DestinationStart: 50, SourceStart: 98, Length: 2},
+		{DestinationStart: 52, SourceStart: 50, Length: 48},
+	}
+	table := NewTable(ranges)
+	assert.Equal(t, Table{
+		{DestinationStart: 0, SourceStart: 0, Length: 50},
+		{DestinationStart: 52, SourceStart: 50, Length: 48},
+		{DestinationStart: 50, SourceStart: 98, Length: 2},
+		{DestinationStart: 100, SourceStart: 100, Length: math.MaxInt64 - 100},
+	}, table)
+
+	a := Almanac{}
+	for v := int64(0); v < 120; v++ {
+		assert.Equal(t, a.Map(v, ranges), table.Map(v))
+	}
+}
+
+func TestNewTableOverlap(t *testing.T) {
+	ranges := []Range{
+		{DestinationStart: 100, SourceStart: 10, Length: 10},
+		{DestinationStart: 200, SourceStart: 5, Length: 20},
+	}
+	table := NewTable(ranges)
+
+	a := Almanac{}
+	for v := int64(0); v < 30; v++ {
+		assert.Equal(t, a.Map(v, ranges), table.Map(v))
+	}
+}
+
+func TestComposeTables(t *testing.T) {
+	a, err := InputToAlmanac(io.NopCloser(bytes.NewReader([]byte(example))), advent.Strict)
+	assert.Nil(t, err)
+
+	table, err := a.Compose(Seed, Location)
+	assert.Nil(t, err)
+	for i := 1; i < len(table); i++ {
+		assert.Equal(t, table[i-1].SourceStart+table[i-1].Length, table[i].SourceStart)
+	}
+
+	path, err := a.Path(Seed, Location)
+	assert.Nil(t, err)
+	for v := int64(0); v < 200; v++ {
+		expected := v
+		for _, m := range path {
+			expected = a.Map(expected, m.Ranges)
+		}
+		assert.Equal(t, expected, table.Map(v), "seed %d", v)
+	}
+
+	_, err = a.Compose(Location, Seed)
+	assert.NotNil(t, err)
+}
+
+func benchmarkAlmanac(b *testing.B) *Almanac {
+	f, err := os.Open("input.txt")
+	if err != nil {
+		b.Skip("input.txt not available")
+	}
+	a, err := InputToAlmanac(f, advent.Strict)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return a
+}
+
+func BenchmarkLocationLinear(b *testing.B) {
+	a := benchmarkAlmanac(b)
+	path, _ := a.Path(Seed, Location)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, s := range a.Seeds {
+			v := s
+			for _, m := range path {
+				v = a.Map(v, m.Ranges)
+			}
+		}
+	}
+}
+
+func BenchmarkLocationTable(b *testing.B) {
+	a := benchmarkAlmanac(b)
+	path, _ := a.Path(Seed, Location)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, s := range a.Seeds {
+			v := s
+			for _, m := range path {
+				v = m.Table.Map(v)
+			}
+		}
+	}
+}
+
+func BenchmarkLocationComposed(b *testing.B) {
+	a := benchmarkAlmanac(b)
+	table, _ := a.Compose(Seed, Location)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, s := range a.Seeds {
+			table.Map(s)
+		}
+	}
+}
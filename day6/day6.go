@@ -0,0 +1,280 @@
+package day6
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/pedrokiefer/adventofcode-2023/advent"
+)
+
+// Race holds big integers since the single race of part two, made of the
+// digits of every race, may not fit in an int.
+type Race struct {
+	Time           *big.Int
+	RecordDistance *big.Int
+}
+
+func NewRace(time, recordDistance int64) Race {
+	return Race{
+		Time:           big.NewInt(time),
+		RecordDistance: big.NewInt(recordDistance),
+	}
+}
+
+// CountPossibleRecords counts the ways of beating the record, see
+// CountRecords.
+func (r Race) CountPossibleRecords() *big.Int {
+	return CountRecords(r.Time, r.RecordDistance)
+}
+
+// CountPossibleRecordsBruteForce tries every hold time.
+func (r Race) CountPossibleRecordsBruteForce() *big.Int {
+	count := big.NewInt(0)
+	one := big.NewInt(1)
+	for h := big.NewInt(0); h.Cmp(r.Time) <= 0; h.Add(h, one) {
+		if wins(h, r.Time, r.RecordDistance) {
+			count.Add(count, one)
+		}
+	}
+	return count
+}
+
+// CountRecords counts the hold times h in [0, t] travelling further than d,
+// that is h*(t-h) > d. Those lie strictly between the roots of
+// h^2 - t*h + d, (t - sqrt(t^2-4d))/2 and its mirror around t/2, so it is
+// enough to find the first winning h near the lower root.
+func CountRecords(t, d *big.Int) *big.Int {
+	zero := big.NewInt(0)
+	one := big.NewInt(1)
+	if t.Sign() < 0 {
+		return zero
+	}
+
+	disc := new(big.Int).Mul(t, t)
+	disc.Sub(disc, new(big.Int).Lsh(d, 2))
+	if disc.Sign() < 0 {
+		return zero
+	}
+
+	// h starts at or just below the lower root, then moves to the first
+	// winning hold time
+	h := new(big.Int).Sub(t, new(big.Int).Sqrt(disc))
+	h.Rsh(h, 1)
+	if h.Sign() < 0 {
+		h.SetInt64(0)
+	}
+	for h.Sign() > 0 && wins(new(big.Int).Sub(h, one), t, d) {
+		h.Sub(h, one)
+	}
+	for !wins(h, t, d) {
+		h.Add(h, one)
+		if new(big.Int).Lsh(h, 1).Cmp(t) > 0 {
+			return zero
+		}
+	}
+
+	// the winning hold times go from h to t-h
+	count := new(big.Int).Sub(t, new(big.Int).Lsh(h, 1))
+	return count.Add(count, one)
+}
+
+func wins(h, t, d *big.Int) bool {
+	travelled := new(big.Int).Sub(t, h)
+	travelled.Mul(travelled, h)
+	return travelled.Cmp(d) > 0
+}
+
+type raceLine struct {
+	Line   int
+	Fields []advent.Field
+}
+
+func readRaceLines(input io.ReadCloser) (raceLine, raceLine, error) {
+	s := bufio.NewScanner(input)
+	defer input.Close()
+	lines := map[string]*raceLine{}
+	n := 0
+	for s.Scan() {
+		n++
+		l := s.Text()
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		label, values, ok := strings.Cut(l, ":")
+		label = strings.TrimSpace(label)
+		if !ok || (label != "Time" && label != "Distance") {
+			return raceLine{}, raceLine{}, &advent.ParseError{
+				Line: n,
+				Text: l,
+				Err:  fmt.Errorf("expected a Time or Distance line"),
+			}
+		}
+		if lines[label] != nil {
+			return raceLine{}, raceLine{}, &advent.ParseError{
+				Line: n,
+				Err:  fmt.Errorf("duplicated %s line", label),
+			}
+		}
+		fields := advent.Fields(values)
+		for i := range fields {
+			fields[i].Column += len(l) - len(values)
+		}
+		lines[label] = &raceLine{Line: n, Fields: fields}
+	}
+	if err := s.Err(); err != nil {
+		return raceLine{}, raceLine{}, err
+	}
+	// a missing line is reported at the end of the input
+	for _, label := range []string{"Time", "Distance"} {
+		if lines[label] == nil {
+			return raceLine{}, raceLine{}, &advent.ParseError{
+				Line: n + 1,
+				Err:  fmt.Errorf("missing %s line", label),
+			}
+		}
+	}
+	return *lines["Time"], *lines["Distance"], nil
+}
+
+func (rl raceLine) numbers() ([]*big.Int, error) {
+	result := []*big.Int{}
+	for _, f := range rl.Fields {
+		v, ok := new(big.Int).SetString(f.Text, 10)
+		if !ok {
+			return nil, &advent.ParseError{
+				Line:   rl.Line,
+				Column: f.Column,
+				Text:   f.Text,
+				Err:    fmt.Errorf("invalid number"),
+			}
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+// number reads the line as a single number, ignoring the spaces between
+// its digits.
+func (rl raceLine) number() (*big.Int, error) {
+	digits := ""
+	for _, f := range rl.Fields {
+		for i, c := range f.Text {
+			if c < '0' || c > '9' {
+				return nil, &advent.ParseError{
+					Line:   rl.Line,
+					Column: f.Column + i,
+					Text:   string(c),
+					Err:    fmt.Errorf("invalid digit"),
+				}
+			}
+		}
+		digits += f.Text
+	}
+	if digits == "" {
+		return nil, &advent.ParseError{Line: rl.Line, Err: fmt.Errorf("missing number")}
+	}
+	v, _ := new(big.Int).SetString(digits, 10)
+	return v, nil
+}
+
+func InputToRace(input io.ReadCloser) ([]Race, error) {
+	tl, dl, err := readRaceLines(input)
+	if err != nil {
+		return nil, err
+	}
+	time, err := tl.numbers()
+	if err != nil {
+		return nil, err
+	}
+	distance, err := dl.numbers()
+	if err != nil {
+		return nil, err
+	}
+	if len(time) != len(distance) {
+		return nil, &advent.ParseError{
+			Line: dl.Line,
+			Err:  fmt.Errorf("expected %d distances, got %d", len(time), len(distance)),
+		}
+	}
+
+	races := []Race{}
+	for i, v := range time {
+		races = append(races, Race{
+			Time:           v,
+			RecordDistance: distance[i],
+		})
+	}
+	return races, nil
+}
+
+// InputToRace2 reads the input as a single race, ignoring the spaces
+// between digits.
+func InputToRace2(input io.ReadCloser) ([]Race, error) {
+	tl, dl, err := readRaceLines(input)
+	if err != nil {
+		return nil, err
+	}
+	time, err := tl.number()
+	if err != nil {
+		return nil, err
+	}
+	distance, err := dl.number()
+	if err != nil {
+		return nil, err
+	}
+	return []Race{{
+		Time:           time,
+		RecordDistance: distance,
+	}}, nil
+}
+
+type Solver struct {
+	input []byte
+}
+
+func init() {
+	advent.Register(6, func() advent.Solver { return &Solver{} })
+}
+
+func (s *Solver) Parse(input io.ReadCloser) error {
+	defer input.Close()
+	b, err := io.ReadAll(input)
+	if err != nil {
+		return err
+	}
+	s.input = b
+	return nil
+}
+
+// answer turns a count into the int a Solver returns.
+func answer(v *big.Int) (int, error) {
+	if !v.IsInt64() || int64(int(v.Int64())) != v.Int64() {
+		return 0, fmt.Errorf("answer %s does not fit in an int", v)
+	}
+	return int(v.Int64()), nil
+}
+
+func (s *Solver) Part1() (int, error) {
+	rs, err := InputToRace(io.NopCloser(bytes.NewReader(s.input)))
+	if err != nil {
+		return 0, err
+	}
+
+	total := big.NewInt(1)
+	for _, r := range rs {
+		total.Mul(total, r.CountPossibleRecords())
+	}
+	return answer(total)
+}
+
+func (s *Solver) Part2() (int, error) {
+	rs, err := InputToRace2(io.NopCloser(bytes.NewReader(s.input)))
+	if err != nil {
+		return 0, err
+	}
+	return answer(rs[0].CountPossibleRecords())
+}
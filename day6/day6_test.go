@@ -1,8 +1,10 @@
-package main
+package day6
 
 import (
 	"bytes"
 	"io"
+	"math/big"
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,29 +14,110 @@ func Test(t *testing.T) {
 	input := io.NopCloser(bytes.NewReader([]byte(`Time:      7  15   30
 Distance:  9  40  200`)))
 
-	v := InputToRace(input)
+	v, err := InputToRace(input)
+	assert.Nil(t, err)
 
 	assert.Equal(t, []Race{
-		{Time: 7, RecordDistance: 9},
-		{Time: 15, RecordDistance: 40},
-		{Time: 30, RecordDistance: 200},
+		NewRace(7, 9),
+		NewRace(15, 40),
+		NewRace(30, 200),
 	}, v)
 
-	assert.Equal(t, 4, v[0].CountPossibleRecords())
-	assert.Equal(t, 8, v[1].CountPossibleRecords())
-	assert.Equal(t, 9, v[2].CountPossibleRecords())
+	assert.Equal(t, big.NewInt(4), v[0].CountPossibleRecords())
+	assert.Equal(t, big.NewInt(8), v[1].CountPossibleRecords())
+	assert.Equal(t, big.NewInt(9), v[2].CountPossibleRecords())
 }
 
 func Test2(t *testing.T) {
 	input := io.NopCloser(bytes.NewReader([]byte(`Time:      7  15   30
 Distance:  9  40  200`)))
 
-	v := InputToRace2(input)
+	v, err := InputToRace2(input)
+	assert.Nil(t, err)
 
-	assert.Equal(t, Race{
-		Time:           71530,
-		RecordDistance: 940200,
-	}, v)
+	assert.Equal(t, []Race{NewRace(71530, 940200)}, v)
+
+	assert.Equal(t, big.NewInt(71503), v[0].CountPossibleRecords())
+}
+
+func TestCountRecordsMatchesBruteForce(t *testing.T) {
+	rnd := rand.New(rand.NewSource(6))
+	for i := 0; i < 2000; i++ {
+		time := rnd.Intn(1000)
+		distance := rnd.Intn(time*time/4+10) - 5
+		if i%2 == 0 {
+			// land exactly on a tie with the record
+			h := rnd.Intn(time + 1)
+			distance = h * (time - h)
+		}
+		r := NewRace(int64(time), int64(distance))
+		assert.Equal(t, r.CountPossibleRecordsBruteForce(), r.CountPossibleRecords(), "%+v", r)
+	}
+}
+
+func TestCountRecordsLargeTimes(t *testing.T) {
+	time, _ := new(big.Int).SetString("1000000000000000000000000000001", 10)
+	h := big.NewInt(123456789)
+
+	assert.Equal(t, new(big.Int).Sub(time, big.NewInt(1)), CountRecords(time, big.NewInt(0)))
+
+	// beating h*(t-h) needs holding from h+1 to t-h-1
+	record := new(big.Int).Mul(h, new(big.Int).Sub(time, h))
+	expected := new(big.Int).Sub(time, new(big.Int).Lsh(h, 1))
+	expected.Sub(expected, big.NewInt(1))
+	assert.Equal(t, expected, CountRecords(time, record))
+
+	// the best possible distance can't be beaten
+	half := new(big.Int).Rsh(time, 1)
+	best := new(big.Int).Mul(half, new(big.Int).Sub(time, half))
+	assert.Equal(t, big.NewInt(0), CountRecords(time, best))
+	assert.Equal(t, big.NewInt(2), CountRecords(time, new(big.Int).Sub(best, big.NewInt(1))))
+}
+
+func TestInputToRaceErrors(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		err   string
+	}{
+		{"Time:      7  15   30\nDistance:  9  40", "line 2: expected 3 distances, got 2"},
+		{"Time:      7  15   30", "line 2: missing Distance line"},
+		{"Distance:  9  40  200", "line 2: missing Time line"},
+		{"", "line 1: missing Time line"},
+		{"Time:      7  1x   30\nDistance:  9  40  200", "line 1, column 15: parsing \"1x\": invalid number"},
+		{"Time:      7  15   30\nSpeed:  9  40  200", "line 2: parsing \"Speed:  9  40  200\": expected a Time or Distance line"},
+		{"Time:      7\nTime:      7\nDistance:  9", "line 2: duplicated Time line"},
+	} {
+		_, err := InputToRace(io.NopCloser(bytes.NewReader([]byte(tc.input))))
+		assert.EqualError(t, err, tc.err, tc.input)
+	}
+}
+
+func TestInputToRaceLargeNumbers(t *testing.T) {
+	input := []byte("Time:      7  15   30\nDistance:  99999999999 99999999999 1")
+
+	v, err := InputToRace2(io.NopCloser(bytes.NewReader(input)))
+	assert.Nil(t, err)
+	distance, _ := new(big.Int).SetString("99999999999999999999991", 10)
+	assert.Equal(t, []Race{{Time: big.NewInt(71530), RecordDistance: distance}}, v)
+	assert.Equal(t, big.NewInt(0), v[0].CountPossibleRecords())
+
+	input = []byte("Time:      700000000000000000000 15   30\nDistance:  9  40  200")
+	s := &Solver{}
+	assert.Nil(t, s.Parse(io.NopCloser(bytes.NewReader(input))))
+	_, err = s.Part2()
+	assert.EqualError(t, err, "answer 7000000000000000000001529 does not fit in an int")
+}
 
-	assert.Equal(t, 71503, v.CountPossibleRecords())
+func TestInputToRace2Errors(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		err   string
+	}{
+		{"Time:      7  15   30", "line 2: missing Distance line"},
+		{"Time:      7  15   30\nDistance:  9  4-0  200", "line 2, column 16: parsing \"-\": invalid digit"},
+		{"Time:\nDistance:  9  40  200", "line 1: missing number"},
+	} {
+		_, err := InputToRace2(io.NopCloser(bytes.NewReader([]byte(tc.input))))
+		assert.EqualError(t, err, tc.err, tc.input)
+	}
 }
@@ -13,5 +13,5 @@ dirName="day$1"
 # Create directories
 mkdir -p $dirName
 
-echo -e "package main\n\nfunc main() {\n\n}\n" > "$dirName/main.go"
-echo -e "package main\n\nimport (\n\t\"bytes\"\n\t\"io\"\n\t\"testing\"\n)\n\nfunc Test(t *testing.T) {\n\tinput := io.NopCloser(bytes.NewReader([]byte(\`\`)))\n\n}\n" > "$dirName/${dirName}_test.go"
+echo -e "package $dirName\n" > "$dirName/$dirName.go"
+echo -e "package $dirName\n\nimport (\n\t\"bytes\"\n\t\"io\"\n\t\"testing\"\n)\n\nfunc Test(t *testing.T) {\n\tinput := io.NopCloser(bytes.NewReader([]byte(\`\`)))\n\n}\n" > "$dirName/${dirName}_test.go"